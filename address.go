@@ -180,11 +180,58 @@ func (f *Fakery) Country() string {
 	return f.RandomString(f.LoadGenericLocale(&addressLoader).Get("countries"))
 }
 
+// Return whether name is a country in the address data
+func (f *Fakery) IsCountry(name string) bool {
+	return containsFold(f.LoadGenericLocale(&addressLoader).Get("countries"), name)
+}
+
 func (f *Fakery) CountryCode() string {
 	return f.RandomString(f.LoadGenericLocale(&addressLoader).Get("country_codes"))
 }
 
 func (f *Fakery) Address() *Address {
+	return f.AddressWith()
+}
+
+// Settings of AddressWith
+type addressSpec struct {
+	city     string
+	state    string
+	country  string
+	building *bool
+}
+
+// Option pinning a field of AddressWith
+type AddressOption func(*addressSpec)
+
+// Pin the city
+func WithCity(city string) AddressOption {
+	return func(s *addressSpec) { s.city = city }
+}
+
+// Pin the state
+func WithState(state string) AddressOption {
+	return func(s *addressSpec) { s.state = state }
+}
+
+// Pin the country, which otherwise is the one of the locale
+func WithCountry(country string) AddressOption {
+	return func(s *addressSpec) { s.country = country }
+}
+
+// Always or never add a building name
+func WithBuilding(building bool) AddressOption {
+	return func(s *addressSpec) { s.building = &building }
+}
+
+// Return a fake Address with the given fields pinned, e.g:
+//
+//	f.AddressWith(fakery.WithState("Texas"))
+func (f *Fakery) AddressWith(opts ...AddressOption) *Address {
+	var spec addressSpec
+	for _, opt := range opts {
+		opt(&spec)
+	}
 
 	var a Address
 	var code string
@@ -192,12 +239,22 @@ func (f *Fakery) Address() *Address {
 	streetAddress := f.RandomString(streetAddressFormats)
 
 	a.Number = f.BuildingNumber()
-	if strings.Contains(streetAddress, "{{buildingName}}") {
+	withBuilding := strings.Contains(streetAddress, "{{buildingName}}")
+	if spec.building != nil {
+		withBuilding = *spec.building
+	}
+	if withBuilding {
 		a.Building = f.BuildingName()
 	}
 	a.Street = f.StreetName()
 	a.City = f.City()
 	a.State = f.State()
+	if spec.city != "" {
+		a.City = spec.city
+	}
+	if spec.state != "" {
+		a.State = spec.state
+	}
 	if f.locale == "en_US" {
 		a.ZipCode = f.ZipCode()
 		code = a.ZipCode
@@ -208,6 +265,9 @@ func (f *Fakery) Address() *Address {
 
 	// get matching country of locale
 	a.Country = f.getCountry()
+	if spec.country != "" {
+		a.Country = spec.country
+	}
 	if a.Building != "" {
 		a.FullAddress = fmt.Sprintf("%s %s, %s, %s - %s, %s, %s", a.Number, a.Building, a.Street, a.City, code, a.State, a.Country)
 	} else {
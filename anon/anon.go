@@ -0,0 +1,228 @@
+// Replace real personal data with consistent fake data
+package anon
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+	"fakery"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// Kind of value to replace
+type Kind string
+
+const (
+	Name       Kind = "name"
+	Email      Kind = "email"
+	Address    Kind = "address"
+	CardNumber Kind = "card_number"
+	Phone      Kind = "phone"
+)
+
+// Kinds lists the supported kinds
+var Kinds = []Kind{Name, Email, Address, CardNumber, Phone}
+
+// An Anonymizer maps real values to fake ones. The fake value is
+// seeded from an HMAC of the real value, so the same customer maps
+// to the same fake everywhere the key is shared, and without the
+// key the mapping can not be reproduced from the fake data.
+type Anonymizer struct {
+	key    []byte
+	locale string
+}
+
+// Create an Anonymizer with the given secret key
+func New(key []byte) *Anonymizer {
+	return NewFromLocale(key, fakery.DefaultLocale)
+}
+
+// Create an Anonymizer generating fakes for the given locale
+func NewFromLocale(key []byte, locale string) *Anonymizer {
+	return &Anonymizer{key: key, locale: locale}
+}
+
+// Replace returns a fake value of the given kind for value. The
+// format is preserved: names keep their gender and prefix, emails
+// keep the class of their domain (free mail or not) and card
+// numbers keep their network and length. Empty values stay empty.
+func (a *Anonymizer) Replace(kind Kind, value string) (string, error) {
+	if strings.TrimSpace(value) == "" {
+		return value, nil
+	}
+
+	switch kind {
+	case Name:
+		return a.name(value), nil
+	case Email:
+		return a.email(value), nil
+	case Address:
+		return a.fakery(kind, normalize(value)).Address().FullAddress, nil
+	case CardNumber:
+		return a.cardNumber(value)
+	case Phone:
+		return a.phone(value)
+	}
+
+	return "", fmt.Errorf("error - unknown kind %q", kind)
+}
+
+// Return a Fakery seeded from the keyed hash of value
+func (a *Anonymizer) fakery(kind Kind, value string) *fakery.Fakery {
+	mac := hmac.New(sha256.New, a.key)
+	mac.Write([]byte(kind))
+	mac.Write([]byte{0})
+	mac.Write([]byte(value))
+
+	f := fakery.NewFromSeed(int64(binary.BigEndian.Uint64(mac.Sum(nil))))
+	f.SetLocale(a.locale)
+	return f
+}
+
+var namePrefixes = []string{"mr", "mrs", "ms", "miss", "dr", "prof"}
+
+func (a *Anonymizer) name(value string) string {
+	f := a.fakery(Name, normalize(value))
+	words := strings.Fields(value)
+
+	var pieces []string
+	// Keep a title such as "Dr." which carries no identity
+	if len(words) > 1 && isPrefix(words[0]) {
+		pieces = append(pieces, words[0])
+		words = words[1:]
+	}
+
+	gender, ok := f.NameGender(words[0])
+	if !ok {
+		gender = f.Gender()
+	}
+	if gender == fakery.GenderMale {
+		pieces = append(pieces, f.FirstNameMale())
+	} else {
+		pieces = append(pieces, f.FirstNameFemale())
+	}
+	if len(words) > 1 {
+		pieces = append(pieces, f.LastName())
+	}
+
+	return matchCase(value, strings.Join(pieces, " "))
+}
+
+func isPrefix(word string) bool {
+	word = strings.ToLower(strings.TrimSuffix(word, "."))
+	for _, p := range namePrefixes {
+		if word == p {
+			return true
+		}
+	}
+	return false
+}
+
+func (a *Anonymizer) email(value string) string {
+	f := a.fakery(Email, normalize(value))
+
+	var domain string
+	if at := strings.LastIndex(value, "@"); at >= 0 {
+		domain = strings.ToLower(strings.TrimSpace(value[at+1:]))
+	}
+
+	// The domain is mapped on its own so that all addresses
+	// of one company share the same fake domain
+	df := a.fakery(Email, domain)
+	if f.IsFreeEmailDomain(domain) {
+		domain = df.FreeEmailDomain()
+	} else {
+		domain = df.EmailDomain()
+	}
+
+	local, _, _ := strings.Cut(f.EmailWithName(f.FirstName(), f.LastName()), "@")
+	return local + "@" + domain
+}
+
+func (a *Anonymizer) cardNumber(value string) (string, error) {
+	digits := onlyDigits(value)
+	if len(digits) < 12 || len(digits) > 19 {
+		return "", fmt.Errorf("error - %q is not a card number", value)
+	}
+
+	// Keep the six digit issuer prefix, which decides the network
+	f := a.fakery(CardNumber, digits)
+	number := f.CreditCardNumberWithPrefix(digits[:6], len(digits))
+
+	return reformat(value, number), nil
+}
+
+func (a *Anonymizer) phone(value string) (string, error) {
+	digits := onlyDigits(value)
+	if len(digits) < 5 {
+		return "", fmt.Errorf("error - %q is not a phone number", value)
+	}
+	f := a.fakery(Phone, digits)
+
+	// Keep the country code when it is separated from the
+	// rest, e.g: +44 20 7946 0958, and a trunk prefix 0
+	keep := 0
+	if strings.HasPrefix(value, "+") {
+		if end := strings.IndexFunc(value[1:], notDigit); end > 0 {
+			keep = end
+		}
+	}
+	for keep < len(digits) && digits[keep] == '0' {
+		keep++
+	}
+
+	fake := []byte(digits[:keep])
+	for len(fake) < len(digits) {
+		fake = append(fake, byte('0'+f.IntRange(10)))
+	}
+
+	return reformat(value, string(fake)), nil
+}
+
+// Lowercase with single spaces
+func normalize(value string) string {
+	return strings.ToLower(strings.Join(strings.Fields(value), " "))
+}
+
+func notDigit(r rune) bool {
+	return r < '0' || r > '9'
+}
+
+func onlyDigits(value string) string {
+	return strings.Map(func(r rune) rune {
+		if notDigit(r) {
+			return -1
+		}
+		return r
+	}, value)
+}
+
+// Put digits into the places of the digits of format
+func reformat(format, digits string) string {
+	var sb strings.Builder
+
+	i := 0
+	for _, r := range format {
+		if !notDigit(r) && i < len(digits) {
+			sb.WriteByte(digits[i])
+			i++
+			continue
+		}
+		sb.WriteRune(r)
+	}
+
+	return sb.String()
+}
+
+// Upper or lower case fake as a whole if value is
+func matchCase(value, fake string) string {
+	switch {
+	case strings.ToUpper(value) == value && strings.IndexFunc(value, unicode.IsLetter) >= 0:
+		return strings.ToUpper(fake)
+	case strings.ToLower(value) == value:
+		return strings.ToLower(fake)
+	}
+	return fake
+}
@@ -0,0 +1,114 @@
+// Anonymize structs from `anon` struct tags
+package anon
+
+import (
+	"database/sql"
+	"fmt"
+	"reflect"
+)
+
+// Anonymize replaces the fields of the struct pointed to by v
+// which carry an `anon` tag naming their kind, e.g:
+//
+//	Email string `anon:"email"`
+//
+// Tagged fields are strings, pointers to strings, string slices
+// and sql.NullString. Untagged structs, pointers to structs and
+// slices of structs are anonymized recursively and `anon:"-"`
+// skips a field.
+func (a *Anonymizer) Anonymize(v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("error - Anonymize needs a pointer to a struct, got %T", v)
+	}
+	return a.anonymizeStruct(rv.Elem())
+}
+
+func (a *Anonymizer) anonymizeStruct(rv reflect.Value) error {
+	rt := rv.Type()
+
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		tag, ok := field.Tag.Lookup("anon")
+		if tag == "-" {
+			continue
+		}
+
+		var err error
+		if ok {
+			err = a.replaceValue(Kind(tag), rv.Field(i))
+		} else {
+			err = a.anonymizeValue(rv.Field(i))
+		}
+		if err != nil {
+			return fmt.Errorf("field %s: %w", field.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// Recurse into untagged values holding structs
+func (a *Anonymizer) anonymizeValue(rv reflect.Value) error {
+	switch rv.Kind() {
+	case reflect.Struct:
+		return a.anonymizeStruct(rv)
+	case reflect.Pointer:
+		if !rv.IsNil() {
+			return a.anonymizeValue(rv.Elem())
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < rv.Len(); i++ {
+			if err := a.anonymizeValue(rv.Index(i)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (a *Anonymizer) replaceValue(kind Kind, rv reflect.Value) error {
+	if ns, ok := rv.Addr().Interface().(*sql.NullString); ok {
+		if !ns.Valid {
+			return nil
+		}
+		return a.replaceString(kind, &ns.String)
+	}
+
+	switch rv.Kind() {
+	case reflect.String:
+		s := rv.String()
+		if err := a.replaceString(kind, &s); err != nil {
+			return err
+		}
+		rv.SetString(s)
+	case reflect.Pointer:
+		if rv.IsNil() {
+			return nil
+		}
+		return a.replaceValue(kind, rv.Elem())
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < rv.Len(); i++ {
+			if err := a.replaceValue(kind, rv.Index(i)); err != nil {
+				return err
+			}
+		}
+	default:
+		return fmt.Errorf("error - cannot anonymize %s", rv.Type())
+	}
+
+	return nil
+}
+
+func (a *Anonymizer) replaceString(kind Kind, s *string) error {
+	fake, err := a.Replace(kind, *s)
+	if err != nil {
+		return err
+	}
+	*s = fake
+	return nil
+}
@@ -0,0 +1,62 @@
+// Streaming and bulk generation
+package fakery
+
+import (
+	"iter"
+	"sync"
+)
+
+// Return an endless stream of values from gen drawing on f.
+// Stop ranging over it to end the stream, e.g:
+//
+//	for p := range fakery.Seq(f, (*fakery.Fakery).Person) { ... }
+func Seq[T any](f *Fakery, gen func(*Fakery) T) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for {
+			if !yield(gen(f)) {
+				return
+			}
+		}
+	}
+}
+
+// Return n values from gen drawing sequentially on f
+func Take[T any](f *Fakery, n int, gen func(*Fakery) T) []T {
+	out := make([]T, 0, MaxInt(n, 0))
+
+	for len(out) < n {
+		out = append(out, gen(f))
+	}
+
+	return out
+}
+
+// Return n values from gen spread across the given number of
+// goroutines. Item i is always generated from f.Derive("batch", i),
+// so the result depends only on the seed of f and never on the
+// number of workers. f itself is not consumed.
+func Batch[T any](f *Fakery, n, workers int, gen func(*Fakery) T) []T {
+	n = MaxInt(n, 0)
+	out := make([]T, n)
+	workers = MaxInt(1, MinInt(workers, n))
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			// Stripe the items over the workers
+			for i := w; i < n; i += workers {
+				out[i] = gen(f.Derive("batch", i))
+			}
+		}(w)
+	}
+	wg.Wait()
+
+	return out
+}
+
+// Return n fake persons
+func (f *Fakery) People(n int) []*Person {
+	return Take(f, n, (*Fakery).Person)
+}
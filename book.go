@@ -384,12 +384,63 @@ func (f *Fakery) BookISBN() string {
 }
 
 func (f *Fakery) Book() *Book {
+	return f.BookWith()
+}
+
+// Settings of BookWith
+type bookSpec struct {
+	author  string
+	genre   string
+	format  string
+	minYear int
+	maxYear int
+}
+
+// Option pinning a field of BookWith
+type BookOption func(*bookSpec)
+
+// Pin the author
+func WithAuthor(author string) BookOption {
+	return func(s *bookSpec) { s.author = author }
+}
+
+// Pin the genre
+func WithGenre(genre string) BookOption {
+	return func(s *bookSpec) { s.genre = genre }
+}
+
+// Pin the format, e.g: Paperback
+func WithBookFormat(format string) BookOption {
+	return func(s *bookSpec) { s.format = format }
+}
+
+// Limit the publication year to [min, max], the bounds being
+// swapped if min > max
+func WithPublicationYears(min, max int) BookOption {
+	return func(s *bookSpec) { s.minYear, s.maxYear = MinInt(min, max), MaxInt(min, max) }
+}
+
+// Return a fake Book with the given fields pinned, e.g:
+//
+//	f.BookWith(fakery.WithGenre("Fantasy"), fakery.WithPublicationYears(2000, 2009))
+func (f *Fakery) BookWith(opts ...BookOption) *Book {
+	spec := bookSpec{minYear: 1980, maxYear: time.Now().Year() - 2}
+	for _, opt := range opts {
+		opt(&spec)
+	}
+
 	var b Book
 
 	b.Title = f.BookTitle()
-	b.Author = f.BookAuthor()
-	b.Genre = f.BookGenre()
-	b.Year = f.BookYear()
+	b.Author = spec.author
+	if b.Author == "" {
+		b.Author = f.BookAuthor()
+	}
+	b.Genre = spec.genre
+	if b.Genre == "" {
+		b.Genre = f.BookGenre()
+	}
+	b.Year = f.RandIntBetween(spec.minYear, spec.maxYear+1)
 	b.Publisher = f.BookPublisher()
 	b.Language = strings.Split(f.locale, "_")[0]
 	if f.Choice() == 1 {
@@ -400,7 +451,10 @@ func (f *Fakery) Book() *Book {
 	// Page count
 	b.PageCount = f.RandIntBetween(100, 501)
 
-	b.Format, _ = f.RandomWeightedItem(&formats)
+	b.Format = spec.format
+	if b.Format == "" {
+		b.Format, _ = f.RandomWeightedItem(&formats)
+	}
 
 	return &b
 }
@@ -3,6 +3,7 @@ package fakery
 
 import (
 	"fmt"
+	"strings"
 	"time"
 )
 
@@ -139,16 +140,78 @@ func (f *Fakery) CarPlate() string {
 
 // Random car
 func (f *Fakery) Car() *Car {
+	return f.CarWith()
+}
+
+// Settings of CarWith
+type carSpec struct {
+	make         string
+	transmission string
+	fuelType     string
+	minYear      int
+	maxYear      int
+}
+
+// Option pinning a field of CarWith
+type CarOption func(*carSpec)
+
+// Pin the make. The model is one of that make if it is known,
+// an unknown make has no model.
+func WithMake(make string) CarOption {
+	return func(s *carSpec) { s.make = make }
+}
+
+// Pin the transmission
+func WithTransmission(transmission string) CarOption {
+	return func(s *carSpec) { s.transmission = transmission }
+}
+
+// Pin the fuel type, e.g: Electric
+func WithFuelType(fuelType string) CarOption {
+	return func(s *carSpec) { s.fuelType = fuelType }
+}
+
+// Limit the manufacturing year to [min, max], the bounds being
+// swapped if min > max
+func WithYearRange(min, max int) CarOption {
+	return func(s *carSpec) { s.minYear, s.maxYear = MinInt(min, max), MaxInt(min, max) }
+}
+
+// Return a fake Car with the given fields pinned, e.g:
+//
+//	f.CarWith(fakery.WithMake("Toyota"))
+func (f *Fakery) CarWith(opts ...CarOption) *Car {
+	spec := carSpec{minYear: 1990, maxYear: time.Now().Year() - 1}
+	for _, opt := range opts {
+		opt(&spec)
+	}
+
 	var car Car
 
-	make, model := f.CarMakeAndModel()
-	car.Make = make
-	car.Model = model
+	if spec.make != "" {
+		car.Make = spec.make
+		// Use the spelling of the data to find the models
+		for _, known := range carData.CarMakers {
+			if strings.EqualFold(known, spec.make) {
+				car.Make = known
+				car.Model = f.makeFromModel(known)
+				break
+			}
+		}
+	} else {
+		car.Make, car.Model = f.CarMakeAndModel()
+	}
 	car.Category = f.CarCategory()
 	car.Series = f.CarSeries()
 	car.Type = f.CarType()
+	if spec.fuelType != "" {
+		car.Type = spec.fuelType
+	}
 	car.Transmission = f.CarTransmission()
-	car.Year = f.RandIntBetween(1990, time.Now().Year())
+	if spec.transmission != "" {
+		car.Transmission = spec.transmission
+	}
+	car.Year = f.RandIntBetween(spec.minYear, spec.maxYear+1)
 	car.Plate = f.CarPlate()
 
 	return &car
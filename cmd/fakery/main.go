@@ -0,0 +1,282 @@
+// The fakery command line tool
+//
+//	fakery person -n 100 -locale en_GB -seed 7 -format csv
+//	fakery list
+//	fakery expand '{{person.name}} <{{internet.email}}>'
+//	fakery dataset schema.yaml -o out/
+//	fakery serve -addr :8080
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"fakery"
+	"fakery/dataset"
+	"fakery/server"
+)
+
+const usage = `Usage:
+  fakery <generator> [flags]       generate values, e.g: fakery person -n 10
+  fakery list                      list the generators
+  fakery expand <template> [flags] expand {{generator}} placeholders
+  fakery dataset <schema> [flags]  generate the tables of a schema file
+  fakery serve [flags]             serve fake data over HTTP
+
+Run a command with -h for its flags.
+`
+
+func main() {
+	if err := run(os.Args[1:], os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, "fakery:", err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string, out io.Writer) error {
+	if len(args) == 0 || args[0] == "-h" || args[0] == "help" {
+		fmt.Fprint(out, usage)
+		return nil
+	}
+
+	switch args[0] {
+	case "list":
+		for _, name := range fakery.Generators() {
+			fmt.Fprintln(out, name)
+		}
+		return nil
+	case "expand":
+		return expand(args[1:], out)
+	case "dataset":
+		return generateDataset(args[1:], out)
+	case "serve":
+		return serve(args[1:], out)
+	}
+	return generate(args[0], args[1:], out)
+}
+
+// Flags shared by the commands
+type options struct {
+	count   int
+	locale  string
+	seed    int64
+	seeded  bool
+	localed bool
+	format  string
+	dialect string
+	table   string
+	outDir  string
+}
+
+func newFlagSet(name string, o *options) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.IntVar(&o.count, "n", 1, "number of values")
+	fs.StringVar(&o.locale, "locale", fakery.DefaultLocale, "locale, e.g: en_US or en_GB")
+	fs.Int64Var(&o.seed, "seed", 0, "seed for reproducible output (default random)")
+	fs.StringVar(&o.format, "format", "json", "output format: json, jsonl, csv, sql or yaml")
+	fs.StringVar(&o.dialect, "dialect", "postgres", "SQL dialect: postgres, mysql or sqlite")
+	fs.StringVar(&o.table, "table", "", "SQL table name (default the generator name)")
+	return fs
+}
+
+// Parse flags which may appear before or after the positional args
+func parse(fs *flag.FlagSet, o *options, args []string) ([]string, error) {
+	var positional []string
+
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		if fs.NArg() == 0 {
+			break
+		}
+		positional = append(positional, fs.Arg(0))
+		args = fs.Args()[1:]
+	}
+
+	fs.Visit(func(fl *flag.Flag) {
+		switch fl.Name {
+		case "seed":
+			o.seeded = true
+		case "locale":
+			o.localed = true
+		}
+	})
+	if o.count < 0 {
+		return nil, fmt.Errorf("-n must not be negative, got %d", o.count)
+	}
+	return positional, nil
+}
+
+func (o *options) fakery() *fakery.Fakery {
+	var f *fakery.Fakery
+
+	if o.seeded {
+		f = fakery.NewFromSeed(o.seed)
+	} else {
+		f = fakery.New()
+	}
+	f.SetLocale(o.locale)
+	return f
+}
+
+func generate(name string, args []string, out io.Writer) error {
+	var o options
+
+	fs := newFlagSet(name, &o)
+	if _, err := parse(fs, &o, args); err != nil {
+		return err
+	}
+
+	gen, ok := fakery.LookupGenerator(name)
+	if !ok {
+		return fmt.Errorf("unknown generator %q, see fakery list", name)
+	}
+
+	f := o.fakery()
+	records := make([]interface{}, o.count)
+	for i := range records {
+		records[i] = gen(f)
+	}
+
+	if o.table == "" {
+		o.table = strings.ReplaceAll(name, ".", "_")
+	}
+	return write(out, records, columnName(name), &o)
+}
+
+// Column name used for scalar values, e.g: "city" for address.city
+func columnName(name string) string {
+	return name[strings.LastIndex(name, ".")+1:]
+}
+
+func expand(args []string, out io.Writer) error {
+	var o options
+
+	fs := newFlagSet("expand", &o)
+	positional, err := parse(fs, &o, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return fmt.Errorf("expand needs one template argument")
+	}
+
+	f := o.fakery()
+	for i := 0; i < o.count; i++ {
+		s, err := f.Expand(positional[0])
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(out, s)
+	}
+	return nil
+}
+
+func generateDataset(args []string, out io.Writer) error {
+	var o options
+
+	fs := newFlagSet("dataset", &o)
+	fs.StringVar(&o.outDir, "o", "", "output directory, one file per table (default stdout)")
+	positional, err := parse(fs, &o, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return fmt.Errorf("dataset needs one schema file argument")
+	}
+
+	s, err := dataset.Load(positional[0])
+	if err != nil {
+		return err
+	}
+	// Flags given on the command line override the schema file
+	if o.seeded {
+		s.Seed = o.seed
+	}
+	if o.localed {
+		s.Locale = o.locale
+	}
+
+	d, err := s.Generate()
+	if err != nil {
+		return err
+	}
+
+	if o.outDir == "" {
+		return writeTables(out, d, &o)
+	}
+
+	if err := os.MkdirAll(o.outDir, 0o755); err != nil {
+		return err
+	}
+
+	for _, t := range d.Tables {
+		o.table = t.Name
+		file, err := os.Create(filepath.Join(o.outDir, t.Name+"."+extension(o.format)))
+		if err != nil {
+			return err
+		}
+
+		err = write(file, tableRows(t), "", &o)
+		if closeErr := file.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return fmt.Errorf("table %s: %w", t.Name, err)
+		}
+	}
+	return nil
+}
+
+// Write all tables to a single stream. The JSON and YAML output
+// is one object keyed by table name, SQL is simply concatenated.
+func writeTables(out io.Writer, d *dataset.Dataset, o *options) error {
+	switch o.format {
+	case "json", "yaml":
+		tables := make(map[string]interface{}, len(d.Tables))
+		for _, t := range d.Tables {
+			tables[t.Name] = tableRows(t)
+		}
+		if o.format == "json" {
+			return writeJSON(out, tables)
+		}
+		return writeYAML(out, tables)
+	case "sql":
+		for _, t := range d.Tables {
+			o.table = t.Name
+			if err := write(out, tableRows(t), "", o); err != nil {
+				return fmt.Errorf("table %s: %w", t.Name, err)
+			}
+		}
+		return nil
+	}
+
+	if len(d.Tables) > 1 {
+		return fmt.Errorf("%s output of several tables needs an output directory (-o)", o.format)
+	}
+	return write(out, tableRows(d.Tables[0]), "", o)
+}
+
+func serve(args []string, out io.Writer) error {
+	var cfg server.Config
+	var addr string
+
+	fs := flag.NewFlagSet("serve", flag.ContinueOnError)
+	fs.StringVar(&addr, "addr", ":8080", "address to listen on")
+	fs.StringVar(&cfg.CORSOrigin, "cors-origin", "*", "allowed CORS origin")
+	fs.DurationVar(&cfg.Latency, "latency", 0, "artificial latency per response, e.g: 200ms")
+	fs.DurationVar(&cfg.Jitter, "jitter", 0, "random extra latency of up to this much")
+	fs.Float64Var(&cfg.ErrorRate, "error-rate", 0, "probability of failing a request with a 5xx")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(out, "fakery serving on %s\n", addr)
+	return http.ListenAndServe(addr, server.New(cfg))
+}
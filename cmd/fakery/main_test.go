@@ -0,0 +1,100 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func runString(t *testing.T, args ...string) string {
+	t.Helper()
+
+	var sb strings.Builder
+	if err := run(args, &sb); err != nil {
+		t.Fatalf("fakery %s: %v", strings.Join(args, " "), err)
+	}
+	return sb.String()
+}
+
+func TestGenerate(t *testing.T) {
+	var people []map[string]interface{}
+
+	out := runString(t, "person", "-n", "3", "-seed", "7", "-locale", "en_GB")
+	if err := json.Unmarshal([]byte(out), &people); err != nil || len(people) != 3 {
+		t.Fatalf("bad json output %q: %v", out, err)
+	}
+	if out != runString(t, "person", "-n", "3", "-seed", "7", "-locale", "en_GB") {
+		t.Error("same seed gave different output")
+	}
+
+	rows, err := csv.NewReader(strings.NewReader(runString(t, "address.city", "-n", "2", "-format", "csv"))).ReadAll()
+	if err != nil || len(rows) != 3 || rows[0][0] != "city" {
+		t.Errorf("bad csv output %v: %v", rows, err)
+	}
+
+	for _, format := range []string{"jsonl", "sql", "yaml"} {
+		for _, name := range []string{"car", "book", "wine", "beer", "color", "emoji", "currency", "user_agent"} {
+			if out := runString(t, name, "-format", format); len(out) == 0 {
+				t.Errorf("no %s output for %s", format, name)
+			}
+		}
+	}
+
+	if err := run([]string{"nope"}, &strings.Builder{}); err == nil {
+		t.Error("expected an error for an unknown generator")
+	}
+	if err := run([]string{"person", "-format", "xml"}, &strings.Builder{}); err == nil {
+		t.Error("expected an error for an unknown format")
+	}
+}
+
+func TestList(t *testing.T) {
+	if !strings.Contains(runString(t, "list"), "internet.email\n") {
+		t.Error("list is missing internet.email")
+	}
+}
+
+func TestExpand(t *testing.T) {
+	out := runString(t, "expand", "{{person.name}} <{{internet.email}}>", "-n", "2")
+	if lines := strings.Split(strings.TrimSpace(out), "\n"); len(lines) != 2 || !strings.Contains(lines[0], "@") {
+		t.Errorf("bad expand output %q", out)
+	}
+}
+
+func TestDataset(t *testing.T) {
+	dir := t.TempDir()
+	schema := filepath.Join(dir, "schema.yaml")
+	os.WriteFile(schema, []byte("tables:\n  users:\n    rows: 4\n    columns:\n      id: seq\n      name: person.name\n"), 0o644)
+
+	runString(t, "dataset", schema, "-o", filepath.Join(dir, "out"), "-format", "csv")
+	data, err := os.ReadFile(filepath.Join(dir, "out", "users.csv"))
+	if err != nil || len(strings.Split(strings.TrimSpace(string(data)), "\n")) != 5 {
+		t.Errorf("bad dataset output %q: %v", data, err)
+	}
+}
+
+func TestBadCount(t *testing.T) {
+	var sb strings.Builder
+	for _, cmd := range []string{"person", "expand"} {
+		if err := run([]string{cmd, "{{person.name}}", "-n", "-1"}, &sb); err == nil {
+			t.Errorf("fakery %s -n -1 should fail", cmd)
+		}
+	}
+}
+
+func TestDatasetLocale(t *testing.T) {
+	dir := t.TempDir()
+	schema := filepath.Join(dir, "schema.yaml")
+	os.WriteFile(schema, []byte("seed: 3\ntables:\n  users:\n    rows: 20\n    columns:\n      name: person.name\n"), 0o644)
+
+	us := runString(t, "dataset", schema, "-format", "csv")
+	if us != runString(t, "dataset", schema, "-format", "csv", "-locale", "en_US") {
+		t.Errorf("-locale en_US should match the default locale")
+	}
+	if us == runString(t, "dataset", schema, "-format", "csv", "-locale", "en_GB") {
+		t.Errorf("-locale en_GB was ignored")
+	}
+}
@@ -0,0 +1,129 @@
+// Output formats of the command line tool
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+
+	"fakery/dataset"
+	"fakery/export"
+
+	"gopkg.in/yaml.v3"
+)
+
+// File extension of an output format
+func extension(format string) string {
+	if format == "yaml" {
+		return "yml"
+	}
+	return format
+}
+
+// Write records in the requested format. Scalar values are
+// wrapped in a single column named column.
+func write(w io.Writer, records []interface{}, column string, o *options) error {
+	switch o.format {
+	case "json":
+		return writeJSON(w, records)
+	case "jsonl":
+		encoder := json.NewEncoder(w)
+		encoder.SetEscapeHTML(false)
+		for _, v := range records {
+			if err := encoder.Encode(v); err != nil {
+				return err
+			}
+		}
+		return nil
+	case "yaml":
+		return writeYAML(w, records)
+	case "csv":
+		c := export.NewCSVWriter(w)
+		for _, r := range records {
+			if err := c.Write(asRecord(r, column)); err != nil {
+				return err
+			}
+		}
+		return c.Flush()
+	case "sql":
+		dialect, err := export.ParseDialect(o.dialect)
+		if err != nil {
+			return err
+		}
+		s := export.NewSQLWriter(w, o.table, dialect)
+		for _, r := range records {
+			if err := s.Write(asRecord(r, column)); err != nil {
+				return err
+			}
+		}
+		return s.Flush()
+	}
+	return fmt.Errorf("unknown format %q", o.format)
+}
+
+// Structs are exported as they are, anything else as a one
+// column row
+func asRecord(v interface{}, column string) interface{} {
+	if _, ok := v.(export.Row); ok {
+		return v
+	}
+
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Pointer {
+		rv = rv.Elem()
+	}
+	if rv.Kind() == reflect.Struct {
+		return v
+	}
+	return export.Row{Columns: []string{column}, Values: []interface{}{v}}
+}
+
+func writeJSON(w io.Writer, v interface{}) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(v)
+}
+
+// YAML is produced from the JSON encoding so that the json tags
+// and the field order of the records are kept
+func writeYAML(w io.Writer, v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	var node yaml.Node
+	if err := yaml.Unmarshal(data, &node); err != nil {
+		return err
+	}
+	blockStyle(&node)
+
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&node); err != nil {
+		return err
+	}
+	_, err = w.Write(buf.Bytes())
+	return err
+}
+
+// Nodes parsed from JSON are in flow style and quoted, reset them
+// to plain block style. Strings which need quotes still get them.
+func blockStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		blockStyle(child)
+	}
+}
+
+func tableRows(t *dataset.Table) []interface{} {
+	rows := make([]interface{}, len(t.Rows))
+	for i, values := range t.Rows {
+		rows[i] = export.Row{Columns: t.Columns, Values: values}
+	}
+	return rows
+}
@@ -0,0 +1,438 @@
+// Dirty data for testing ETL pipelines and validators
+package fakery
+
+import (
+	"fmt"
+	"iter"
+	"reflect"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding/charmap"
+)
+
+// Kind of data corruption
+type Corruption string
+
+const (
+	// Keyboard adjacent key, dropped, doubled or transposed letter
+	CorruptTypo Corruption = "typo"
+	// Stray leading, trailing or doubled whitespace
+	CorruptWhitespace Corruption = "whitespace"
+	// Upper, lower or mixed casing
+	CorruptCasing Corruption = "casing"
+	// Value cut short
+	CorruptTruncate Corruption = "truncate"
+	// Values of two string fields of a record swapped
+	CorruptSwap Corruption = "swap"
+	// UTF-8 read as Latin-1 (mojibake) or a replacement character
+	CorruptEncoding Corruption = "encoding"
+	// Record emitted twice
+	CorruptDuplicate Corruption = "duplicate"
+	// Number negated or far too large
+	CorruptOutOfRange Corruption = "out_of_range"
+)
+
+// Corruptions lists all kinds of corruption
+var Corruptions = []Corruption{CorruptTypo, CorruptWhitespace, CorruptCasing, CorruptTruncate,
+	CorruptSwap, CorruptEncoding, CorruptDuplicate, CorruptOutOfRange}
+
+// Probability of each kind of corruption per value, or per record
+// for CorruptSwap and CorruptDuplicate
+type CorruptRates map[Corruption]float64
+
+// Ground truth of one corruption. For a duplicate Before holds the
+// position of the original record.
+type CorruptionEvent struct {
+	// Position of the record in the stream, from 0
+	Record int        `json:"record"`
+	Field  string     `json:"field,omitempty"`
+	Kind   Corruption `json:"kind"`
+	Before string     `json:"before"`
+	After  string     `json:"after"`
+}
+
+// A Corrupter damages values and records at configured rates and
+// logs every corruption it makes
+type Corrupter struct {
+	f      *Fakery
+	rates  CorruptRates
+	record int
+	log    []CorruptionEvent
+}
+
+// Return a Corrupter drawing on f with the given rates, e.g:
+//
+//	c := f.Corrupt(fakery.CorruptRates{fakery.CorruptTypo: 0.05})
+func (f *Fakery) Corrupt(rates CorruptRates) *Corrupter {
+	return &Corrupter{f: f, rates: rates}
+}
+
+// Return the corruptions made so far
+func (c *Corrupter) Log() []CorruptionEvent {
+	return c.log
+}
+
+// Return s, possibly corrupted, as one record
+func (c *Corrupter) String(s string) string {
+	defer c.next()
+	return c.corruptString(s, "")
+}
+
+// Corrupt the struct pointed to by v in place as one record.
+// String fields may get any of the value corruptions, numeric
+// fields may go out of range and two string fields may be
+// swapped. Fields are logged by their json names.
+func (c *Corrupter) Record(v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("error - Record needs a pointer to a struct, got %T", v)
+	}
+
+	defer c.next()
+	c.corruptRecord(rv.Elem())
+	return nil
+}
+
+// Wrap gen so that its values are corrupted, each value being
+// one record
+func (c *Corrupter) Generator(gen Generator) Generator {
+	return func(f *Fakery) interface{} {
+		return c.Value(gen(f))
+	}
+}
+
+// Return v corrupted as one record. Strings and numbers are
+// returned corrupted, pointers to structs are corrupted in place.
+func (c *Corrupter) Value(v interface{}) interface{} {
+	defer c.next()
+
+	rv := reflect.ValueOf(v)
+	switch {
+	case !rv.IsValid():
+	case rv.Kind() == reflect.String:
+		// Of the same type, which may be a named one
+		out := reflect.New(rv.Type()).Elem()
+		out.SetString(c.corruptString(rv.String(), ""))
+		return out.Interface()
+	case rv.CanInt() || rv.CanUint() || rv.CanFloat():
+		return c.corruptNumber(rv, "").Interface()
+	case rv.Kind() == reflect.Pointer && !rv.IsNil() && rv.Elem().Kind() == reflect.Struct:
+		c.corruptRecord(rv.Elem())
+	}
+	return v
+}
+
+// Return seq with every value corrupted by c and duplicated at
+// the CorruptDuplicate rate
+func CorruptSeq[T any](c *Corrupter, seq iter.Seq[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for v := range seq {
+			v = c.Value(v).(T)
+			if !yield(v) {
+				return
+			}
+
+			if c.f.Chance(c.rates[CorruptDuplicate]) {
+				dup := duplicate(v)
+				c.logEvent("", CorruptDuplicate, fmt.Sprint(c.record-1), "")
+				c.next()
+				if !yield(dup) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Return a copy of v, so that corrupting the copy in place
+// later does not change the original
+func duplicate[T any](v T) T {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() {
+		return v
+	}
+	dup := reflect.New(rv.Type().Elem())
+	dup.Elem().Set(rv.Elem())
+	return dup.Interface().(T)
+}
+
+func (c *Corrupter) next() {
+	c.record++
+}
+
+func (c *Corrupter) logEvent(field string, kind Corruption, before, after string) {
+	c.log = append(c.log, CorruptionEvent{Record: c.record, Field: field, Kind: kind, Before: before, After: after})
+}
+
+// Apply each string corruption at its rate
+func (c *Corrupter) corruptString(s, field string) string {
+	corruptions := []struct {
+		kind Corruption
+		fn   func(string) string
+	}{
+		{CorruptTypo, c.typo},
+		{CorruptWhitespace, c.whitespace},
+		{CorruptCasing, c.casing},
+		{CorruptTruncate, c.truncate},
+		{CorruptEncoding, c.encoding},
+	}
+
+	for _, cr := range corruptions {
+		if s == "" || !c.f.Chance(c.rates[cr.kind]) {
+			continue
+		}
+		if after := cr.fn(s); after != s {
+			c.logEvent(field, cr.kind, s, after)
+			s = after
+		}
+	}
+
+	return s
+}
+
+// Return the number held by v, possibly out of range
+func (c *Corrupter) corruptNumber(v reflect.Value, field string) reflect.Value {
+	if !c.f.Chance(c.rates[CorruptOutOfRange]) {
+		return v
+	}
+
+	out := reflect.New(v.Type()).Elem()
+	switch {
+	case v.CanInt():
+		n := v.Int()
+		if c.f.Choice() == 0 || n == 0 {
+			n = -n - 1
+		} else {
+			n *= 1000
+		}
+		out.SetInt(n)
+	case v.CanUint():
+		// Unsigned values can only overflow upwards
+		out.SetUint(v.Uint()*1000 + 1)
+	case v.CanFloat():
+		n := v.Float()
+		if c.f.Choice() == 0 {
+			n = -n - 1
+		} else {
+			n *= 1000
+		}
+		out.SetFloat(n)
+	default:
+		return v
+	}
+
+	c.logEvent(field, CorruptOutOfRange, fmt.Sprint(v.Interface()), fmt.Sprint(out.Interface()))
+	return out
+}
+
+func (c *Corrupter) corruptRecord(rv reflect.Value) {
+	fields := recordFields(rv, "", map[visit]bool{})
+
+	var strs []recordField
+	for _, fd := range fields {
+		switch {
+		case fd.value.Kind() == reflect.String:
+			fd.value.SetString(c.corruptString(fd.value.String(), fd.name))
+			strs = append(strs, fd)
+		case fd.value.CanInt() || fd.value.CanUint() || fd.value.CanFloat():
+			fd.value.Set(c.corruptNumber(fd.value, fd.name))
+		}
+	}
+
+	if len(strs) > 1 && c.f.Chance(c.rates[CorruptSwap]) {
+		idx := c.f.Permutation(len(strs))
+		a, b := strs[idx[0]], strs[idx[1]]
+
+		va, vb := a.value.String(), b.value.String()
+		a.value.SetString(vb)
+		b.value.SetString(va)
+		c.logEvent(a.name+","+b.name, CorruptSwap, va+","+vb, vb+","+va)
+	}
+}
+
+type recordField struct {
+	name  string
+	value reflect.Value
+}
+
+// A struct reached while walking a record
+type visit struct {
+	addr uintptr
+	typ  reflect.Type
+}
+
+// Settable fields of a struct, nested ones with dotted names.
+// Structs already visited through pointers are skipped, which ends
+// cycles such as a node pointing to itself.
+func recordFields(rv reflect.Value, prefix string, visited map[visit]bool) []recordField {
+	var fields []recordField
+	rt := rv.Type()
+
+	if rv.CanAddr() {
+		key := visit{rv.Addr().Pointer(), rt}
+		if visited[key] {
+			return nil
+		}
+		visited[key] = true
+	}
+
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name := field.Name
+		if tag, _, _ := strings.Cut(field.Tag.Get("json"), ","); tag == "-" {
+			continue
+		} else if tag != "" {
+			name = tag
+		}
+		name = prefix + name
+
+		fv := rv.Field(i)
+		if fv.Kind() == reflect.Pointer && !fv.IsNil() {
+			fv = fv.Elem()
+		}
+		if fv.Kind() == reflect.Struct {
+			fields = append(fields, recordFields(fv, name+".", visited)...)
+			continue
+		}
+		fields = append(fields, recordField{name: name, value: fv})
+	}
+
+	return fields
+}
+
+// Keys adjacent to each letter on a QWERTY keyboard
+var keyboardAdjacent = func() map[rune][]rune {
+	rows := []string{"qwertyuiop", "asdfghjkl", "zxcvbnm"}
+	adjacent := make(map[rune][]rune)
+
+	at := func(row, col int) (rune, bool) {
+		if row < 0 || row >= len(rows) || col < 0 || col >= len(rows[row]) {
+			return 0, false
+		}
+		return rune(rows[row][col]), true
+	}
+
+	// Rows are staggered, so the keys above are at the same
+	// and next column, the keys below at the same and previous
+	for r, row := range rows {
+		for col, key := range row {
+			for _, pos := range [][2]int{{r, col - 1}, {r, col + 1}, {r - 1, col}, {r - 1, col + 1}, {r + 1, col - 1}, {r + 1, col}} {
+				if k, ok := at(pos[0], pos[1]); ok {
+					adjacent[key] = append(adjacent[key], k)
+				}
+			}
+		}
+	}
+
+	return adjacent
+}()
+
+// Introduce a typo at a random letter
+func (c *Corrupter) typo(s string) string {
+	runes := []rune(s)
+
+	var letters []int
+	for i, r := range runes {
+		if unicode.IsLetter(r) {
+			letters = append(letters, i)
+		}
+	}
+	if len(letters) == 0 {
+		return s
+	}
+	i := Pick(c.f, letters)
+
+	switch c.f.IntRange(4) {
+	case 0:
+		// Hit a neighbouring key
+		if keys, ok := keyboardAdjacent[unicode.ToLower(runes[i])]; ok {
+			key := Pick(c.f, keys)
+			if unicode.IsUpper(runes[i]) {
+				key = unicode.ToUpper(key)
+			}
+			runes[i] = key
+			return string(runes)
+		}
+		fallthrough
+	case 1:
+		// Drop the letter
+		return string(append(runes[:i:i], runes[i+1:]...))
+	case 2:
+		// Double the letter
+		return string(runes[:i+1]) + string(runes[i:])
+	default:
+		// Swap with the next character
+		if i+1 < len(runes) {
+			runes[i], runes[i+1] = runes[i+1], runes[i]
+		}
+		return string(runes)
+	}
+}
+
+func (c *Corrupter) whitespace(s string) string {
+	switch c.f.IntRange(4) {
+	case 0:
+		return " " + s
+	case 1:
+		return s + Pick(c.f, []string{" ", "  ", "\t", "\n"})
+	case 2:
+		// Non breaking space
+		return strings.Replace(s, " ", "\u00a0", 1) + "\u00a0"
+	default:
+		if i := strings.IndexByte(s, ' '); i >= 0 {
+			return s[:i] + " " + s[i:]
+		}
+		return s + " "
+	}
+}
+
+func (c *Corrupter) casing(s string) string {
+	switch c.f.IntRange(3) {
+	case 0:
+		return strings.ToUpper(s)
+	case 1:
+		return strings.ToLower(s)
+	default:
+		runes := []rune(s)
+		for i, r := range runes {
+			if c.f.Choice() == 0 {
+				runes[i] = unicode.ToUpper(r)
+			} else {
+				runes[i] = unicode.ToLower(r)
+			}
+		}
+		return string(runes)
+	}
+}
+
+func (c *Corrupter) truncate(s string) string {
+	runes := []rune(s)
+	if len(runes) < 2 {
+		return s
+	}
+	return string(runes[:c.f.RandIntBetween(1, len(runes))])
+}
+
+// Mangle the encoding of s as a system reading UTF-8 as Latin-1
+// would, or with a replacement character for ASCII strings
+func (c *Corrupter) encoding(s string) string {
+	for _, r := range s {
+		if r >= utf8.RuneSelf {
+			mangled, err := charmap.Windows1252.NewDecoder().String(s)
+			if err == nil {
+				return mangled
+			}
+			break
+		}
+	}
+
+	runes := []rune(s)
+	runes[c.f.IntRange(len(runes))] = utf8.RuneError
+	return string(runes)
+}
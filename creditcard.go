@@ -3,7 +3,8 @@ package fakery
 
 import (
 	"fmt"
-	"math/rand"
+	"hash/fnv"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -15,26 +16,113 @@ type CreditCard struct {
 	CVV        string `json:"cvv"`
 	ExpiryDate string `json:"expiry_date"`
 	Name       string `json:"name"`
+	BINInfo
+	MaskedNumber string `json:"masked_number"` // 411111******1111
+	TokenNumber  string `json:"token_number"`  // Network token standing in for the number
+	Track1       string `json:"track1"`        // %B4111111111111111^DOE/JANE^2712201...?
+	Track2       string `json:"track2"`        // ;4111111111111111=2712201...?
 	Base
 }
 
+// Metadata of the bank identification number, the first six
+// digits of a card number
+type BINInfo struct {
+	BIN           string `json:"bin"`            // 411111
+	Issuer        string `json:"issuer"`         // Chase
+	IssuerCountry string `json:"issuer_country"` // US
+	Level         string `json:"level"`          // Classic, Gold or Platinum
+	Funding       string `json:"funding"`        // credit, debit or prepaid
+}
+
 func (c CreditCard) String() string {
 	return c.Base.String(c)
 }
 
+// Return whether the number, with or without spaces, passes the
+// Luhn check
 func (c *CreditCard) Validate() bool {
-	return luhnCheck(c.Number)
+	number := stripIDSeparators(c.Number)
+	if _, ok := digitsOf(number); !ok || number == "" {
+		return false
+	}
+	return luhnCheck(number)
 }
 
 var creditCardTypes = WeightedArray{
 	Items: []WeightedItem{
-		{Item: "VISA", Weight: 0.4},
-		{Item: "MasterCard", Weight: 0.4},
-		{Item: "AMEX", Weight: 0.1},
-		{Item: "Discover", Weight: 0.1},
+		{Item: "VISA", Weight: 0.38},
+		{Item: "MasterCard", Weight: 0.30},
+		{Item: "AMEX", Weight: 0.08},
+		{Item: "Discover", Weight: 0.06},
+		{Item: "UnionPay", Weight: 0.05},
+		{Item: "JCB", Weight: 0.03},
+		{Item: "Maestro", Weight: 0.03},
+		{Item: "Diners Club", Weight: 0.02},
+		{Item: "RuPay", Weight: 0.02},
+		{Item: "Mir", Weight: 0.02},
+		{Item: "Elo", Weight: 0.01},
 	},
 }
 
+// A card network with its IIN ranges, the inclusive ranges of
+// prefixes, the lengths of its numbers and gateway test numbers
+type cardNetwork struct {
+	name        string
+	iins        [][2]int
+	lengths     []int
+	testNumbers []string
+}
+
+var cardNetworks = []cardNetwork{
+	{"VISA", [][2]int{{4, 4}}, []int{13, 16, 19},
+		[]string{"4242424242424242", "4111111111111111", "4012888888881881", "4000056655665556"}},
+	{"MasterCard", [][2]int{{51, 55}, {2221, 2720}}, []int{16},
+		[]string{"5555555555554444", "5105105105105100", "2223003122003222", "5200828282828210"}},
+	{"AMEX", [][2]int{{34, 34}, {37, 37}}, []int{15},
+		[]string{"378282246310005", "371449635398431", "378734493671000"}},
+	{"Discover", [][2]int{{6011, 6011}, {644, 649}, {65, 65}}, []int{16, 19},
+		[]string{"6011111111111117", "6011000990139424"}},
+	{"JCB", [][2]int{{3528, 3589}}, []int{16, 17, 18, 19},
+		[]string{"3530111333300000", "3566002020360505"}},
+	{"Diners Club", [][2]int{{300, 305}, {3095, 3095}, {36, 36}, {38, 39}}, []int{14},
+		[]string{"30569309025904", "38520000023237"}},
+	{"UnionPay", [][2]int{{62, 62}}, []int{16, 17, 18, 19},
+		[]string{"6200000000000005"}},
+	{"Maestro", [][2]int{{5018, 5018}, {5020, 5020}, {5038, 5038}, {5893, 5893}, {6304, 6304},
+		{6759, 6759}, {6761, 6763}}, []int{12, 13, 14, 15, 16, 17, 18, 19},
+		nil},
+	{"RuPay", [][2]int{{508, 508}, {60, 60}, {6521, 6522}, {81, 82}}, []int{16},
+		nil},
+	{"Mir", [][2]int{{2200, 2204}}, []int{16, 17, 18, 19},
+		nil},
+	{"Elo", [][2]int{{401178, 401179}, {431274, 431274}, {438935, 438935}, {451416, 451416},
+		{457393, 457393}, {457631, 457632}, {504175, 504175}, {506699, 506778}, {509000, 509999},
+		{627780, 627780}, {636297, 636297}, {636368, 636368}, {650031, 650033}, {650035, 650051},
+		{650405, 650439}, {650485, 650538}, {650541, 650598}, {650700, 650718}, {650720, 650727},
+		{650901, 650920}, {651652, 651679}, {655000, 655019}, {655021, 655058}}, []int{16},
+		nil},
+}
+
+// Names of the supported card networks
+var CardNetworks = func() []string {
+	names := make([]string, len(cardNetworks))
+	for i, network := range cardNetworks {
+		names[i] = network.name
+	}
+	return names
+}()
+
+// Return the network of the given name, ignoring case and spaces
+func cardNetworkByName(name string) *cardNetwork {
+	name = strings.ReplaceAll(name, " ", "")
+	for i := range cardNetworks {
+		if strings.EqualFold(strings.ReplaceAll(cardNetworks[i].name, " ", ""), name) {
+			return &cardNetworks[i]
+		}
+	}
+	return nil
+}
+
 // LuhnCheck computes if a number passes the Luhn algorithm
 func luhnCheck(card string) bool {
 	sum := 0
@@ -54,30 +142,22 @@ func luhnCheck(card string) bool {
 	return sum%10 == 0
 }
 
-// generateValid completes a valid card number from prefix and total length
-func generateValid(prefix string, length int) string {
-	for {
-		num := prefix
-		for i := 0; i < length-len(prefix)-1; i++ {
-			num += strconv.Itoa(rand.Intn(10))
-		}
-		for d := 0; d <= 9; d++ {
-			testNum := num + strconv.Itoa(d)
-			if luhnCheck(testNum) {
-				return testNum
-			}
-		}
-	}
-}
+// Return a Luhn valid card number of the given length
+// starting with prefix
+func (f *Fakery) CreditCardNumberWithPrefix(prefix string, length int) string {
+	var sb strings.Builder
 
-// generate returns a random but unchecked credit card number
-func generate(prefix string, length int) string {
-	num := prefix
-	for i := 0; i < length-len(prefix); i++ {
-		num += strconv.Itoa(rand.Intn(10))
+	sb.WriteString(prefix)
+	for sb.Len() < length-1 {
+		sb.WriteByte(byte('0' + f.IntRange(10)))
 	}
 
-	//	fmt.Println(luhnCheck(num))
+	num := sb.String()
+	for d := 0; d <= 9; d++ {
+		if luhnCheck(num + strconv.Itoa(d)) {
+			return num + strconv.Itoa(d)
+		}
+	}
 	return num
 }
 
@@ -90,30 +170,98 @@ func (f *Fakery) CreditCardType() string {
 	return f.CreditCardCompany()
 }
 
-// Generates a card number for the given type
+// Generates a Luhn valid card number for the given type, with
+// the IIN ranges and lengths of its network
 func (f *Fakery) CreditCardNumber(cardType string) string {
+	network := cardNetworkByName(cardType)
+	if network == nil {
+		return "unsupported card type"
+	}
+	return f.cardNumber(network, 0)
+}
 
-	switch strings.ToLower(cardType) {
-	case "visa":
-		return generate("4", 16)
-	case "mastercard":
-		// Use 51-55 or 2221-2720
-		prefix := ""
-		if rand.Intn(2) == 0 {
-			prefix = strconv.Itoa(51 + rand.Intn(5)) // 51-55
-		} else {
-			prefix = strconv.Itoa(2221 + rand.Intn(500)) // 2221-2720
+// Return a number of the network of the given length, or of one
+// of its lengths for 0
+func (f *Fakery) cardNumber(network *cardNetwork, length int) string {
+	if length == 0 {
+		length = Pick(f, network.lengths)
+	}
+
+	for {
+		iin := Pick(f, network.iins)
+		prefix := strconv.Itoa(f.RandIntBetween(iin[0], iin[1]+1))
+		number := f.CreditCardNumberWithPrefix(prefix, length)
+		// Redraw numbers falling in a narrower range of another
+		// network, e.g. Elo's 401178 within VISA's 4
+		if CardNetworkFromNumber(number) == network.name {
+			return number
 		}
-		return generate(prefix, 16)
-	case "amex":
-		amexPrefixes := []string{"34", "37"}
-		return generate(amexPrefixes[rand.Intn(2)], 15)
-	case "discover":
-		discoverPrefixes := []string{"6011", "65", "644", "645", "646", "647", "648", "649"}
-		return generate(discoverPrefixes[rand.Intn(len(discoverPrefixes))], 16)
+	}
+}
+
+// Return the network of a card number, e.g. VISA, or "" if no
+// network issues numbers of its prefix and length
+func CardNetworkFromNumber(number string) string {
+	number = stripIDSeparators(number)
+	if _, ok := digitsOf(number); !ok {
+		return ""
 	}
 
-	return "unsupported card type"
+	found, foundLen := "", 0
+	for _, network := range cardNetworks {
+		if !slices.Contains(network.lengths, len(number)) {
+			continue
+		}
+		for _, iin := range network.iins {
+			n := len(strconv.Itoa(iin[0]))
+			if n <= foundLen || n > len(number) {
+				continue
+			}
+			// The network with the longest matching prefix wins
+			if prefix, _ := strconv.Atoi(number[:n]); prefix >= iin[0] && prefix <= iin[1] {
+				found, foundLen = network.name, n
+			}
+		}
+	}
+	return found
+}
+
+// Return a card number in groups as printed on cards, 4-6-5 for
+// AMEX, 4-6-4 for Diners Club and 4-4-4-4 for most others
+func FormatCardNumber(number string) string {
+	number = stripIDSeparators(number)
+
+	groups := []int{4, 6, 5}
+	switch len(number) {
+	case 14:
+		groups = []int{4, 6, 4}
+	case 15:
+	default:
+		groups = nil
+		for n := len(number); n > 0; n -= 4 {
+			groups = append(groups, min(n, 4))
+		}
+	}
+
+	parts := make([]string, 0, len(groups))
+	for _, n := range groups {
+		if n > len(number) {
+			n = len(number)
+		}
+		parts = append(parts, number[:n])
+		number = number[n:]
+	}
+	return strings.Join(parts, " ")
+}
+
+// Return a documented test number of the payment gateways for
+// the given type, or "" if there is none
+func (f *Fakery) CreditCardTestNumber(cardType string) string {
+	network := cardNetworkByName(cardType)
+	if network == nil || len(network.testNumbers) == 0 {
+		return ""
+	}
+	return Pick(f, network.testNumbers)
 }
 
 func (f *Fakery) CreditCardExpiryDate() string {
@@ -135,13 +283,208 @@ func (f *Fakery) CreditCardCVV(cardType string) string {
 }
 
 func (f *Fakery) CreditCard() *CreditCard {
+	return f.CreditCardWith()
+}
+
+// Settings of CreditCardWith
+type creditCardSpec struct {
+	cardType   string
+	name       string
+	testNumber bool
+	formatted  bool
+}
+
+// Option pinning a field of CreditCardWith
+type CreditCardOption func(*creditCardSpec)
+
+// Pin the card type, e.g: VISA. An unsupported type is replaced
+// by a random supported one.
+func WithCardType(cardType string) CreditCardOption {
+	return func(s *creditCardSpec) { s.cardType = cardType }
+}
+
+// Pin the name of the card holder
+func WithCardHolder(name string) CreditCardOption {
+	return func(s *creditCardSpec) { s.name = name }
+}
+
+// Use only numbers documented as test cards by payment gateways.
+// Types without any, such as Mir, are replaced by one with them.
+func WithTestNumber() CreditCardOption {
+	return func(s *creditCardSpec) { s.testNumber = true }
+}
+
+// Print the number in groups, see FormatCardNumber
+func WithFormattedNumber() CreditCardOption {
+	return func(s *creditCardSpec) { s.formatted = true }
+}
+
+// Return a fake CreditCard with the given fields pinned, e.g:
+//
+//	f.CreditCardWith(fakery.WithCardType("AMEX"))
+func (f *Fakery) CreditCardWith(opts ...CreditCardOption) *CreditCard {
+	var spec creditCardSpec
+	for _, opt := range opts {
+		opt(&spec)
+	}
+
 	var c CreditCard
 
-	c.Type = f.CreditCardType()
-	c.Number = f.CreditCardNumber(c.Type)
+	c.Type = spec.cardType
+	if cardNetworkByName(c.Type) == nil {
+		c.Type = f.CreditCardType()
+	}
+	if spec.testNumber {
+		for c.Number = f.CreditCardTestNumber(c.Type); c.Number == ""; c.Number = f.CreditCardTestNumber(c.Type) {
+			c.Type = f.CreditCardType()
+		}
+	} else {
+		c.Number = f.CreditCardNumber(c.Type)
+	}
+	if spec.formatted {
+		c.Number = FormatCardNumber(c.Number)
+	}
 	c.CVV = f.CreditCardCVV(c.Type)
 	c.ExpiryDate = f.CreditCardExpiryDate()
-	c.Name = f.Name()
+	c.Name = spec.name
+	if c.Name == "" {
+		c.Name = f.Name()
+	}
+
+	c.BINInfo, _ = LookupBIN(c.Number)
+	c.MaskedNumber = MaskCardNumber(c.Number)
+	c.TokenNumber = f.CreditCardToken(c.Number)
+	c.Track1, c.Track2 = f.cardTracks(&c)
 
 	return &c
 }
+
+// A bank issuing cards and its country
+type cardIssuer struct {
+	name    string
+	country string
+}
+
+// Issuers by network; VISA and MasterCard share theirs
+var cardIssuers = map[string][]cardIssuer{
+	"VISA": {{"Chase", "US"}, {"Bank of America", "US"}, {"Wells Fargo", "US"}, {"Citibank", "US"},
+		{"Capital One", "US"}, {"U.S. Bank", "US"}, {"Barclays", "GB"}, {"HSBC", "GB"}, {"Lloyds Bank", "GB"},
+		{"Deutsche Bank", "DE"}, {"BNP Paribas", "FR"}, {"Santander", "ES"}, {"ING", "NL"},
+		{"RBC Royal Bank", "CA"}, {"TD Bank", "CA"}, {"Commonwealth Bank", "AU"}, {"HDFC Bank", "IN"}},
+	"AMEX":        {{"American Express", "US"}},
+	"Discover":    {{"Discover Bank", "US"}},
+	"JCB":         {{"JCB Co.", "JP"}, {"Sumitomo Mitsui Card", "JP"}, {"Rakuten Card", "JP"}, {"MUFG Bank", "JP"}},
+	"Diners Club": {{"Diners Club International", "US"}},
+	"UnionPay": {{"Bank of China", "CN"}, {"Industrial and Commercial Bank of China", "CN"},
+		{"China Construction Bank", "CN"}, {"Agricultural Bank of China", "CN"}},
+	"Maestro": {{"ING", "NL"}, {"Deutsche Bank", "DE"}, {"Santander", "ES"}, {"KBC", "BE"},
+		{"Raiffeisen Bank", "AT"}, {"Intesa Sanpaolo", "IT"}},
+	"RuPay": {{"State Bank of India", "IN"}, {"Punjab National Bank", "IN"}, {"Bank of Baroda", "IN"},
+		{"Canara Bank", "IN"}},
+	"Mir": {{"Sberbank", "RU"}, {"VTB", "RU"}, {"Gazprombank", "RU"}, {"Alfa-Bank", "RU"}},
+	"Elo": {{"Banco do Brasil", "BR"}, {"Bradesco", "BR"}, {"Caixa Econômica Federal", "BR"}},
+}
+
+var (
+	cardLevels  = []string{"Classic", "Gold", "Platinum"}
+	cardFunding = []string{"credit", "debit", "prepaid"}
+)
+
+// Return the metadata of the BIN of a card number. The issuer,
+// level and funding are drawn from the BIN itself, so all cards
+// sharing a BIN agree. The result is false for numbers of no
+// known network.
+func LookupBIN(number string) (BINInfo, bool) {
+	number = stripIDSeparators(number)
+	network := CardNetworkFromNumber(number)
+	if network == "" {
+		return BINInfo{}, false
+	}
+
+	h := fnv.New64a()
+	h.Write([]byte(number[:6]))
+	bf := NewFromSeed(int64(h.Sum64()))
+
+	issuers, ok := cardIssuers[network]
+	if !ok {
+		issuers = cardIssuers["VISA"]
+	}
+	issuer := Pick(bf, issuers)
+	level, _ := WeightedPick(bf, cardLevels, []float64{0.6, 0.25, 0.15})
+	funding, _ := WeightedPick(bf, cardFunding, []float64{0.55, 0.4, 0.05})
+
+	return BINInfo{
+		BIN:           number[:6],
+		Issuer:        issuer.name,
+		IssuerCountry: issuer.country,
+		Level:         level,
+		Funding:       funding,
+	}, true
+}
+
+// Return the number with all but the BIN and the last four
+// digits masked, e.g. 411111******1111
+func MaskCardNumber(number string) string {
+	number = stripIDSeparators(number)
+	if len(number) < 11 {
+		return strings.Repeat("*", len(number))
+	}
+	return number[:6] + strings.Repeat("*", len(number)-10) + number[len(number)-4:]
+}
+
+// Return a network token for a card number: a different Luhn
+// valid number of the same network and length, as issued by
+// token services in place of the card number. Numbers of no known
+// network have no token.
+func (f *Fakery) CreditCardToken(number string) string {
+	number = stripIDSeparators(number)
+	network := cardNetworkByName(CardNetworkFromNumber(number))
+	if network == nil {
+		return ""
+	}
+
+	for {
+		if token := f.cardNumber(network, len(number)); token != number {
+			return token
+		}
+	}
+}
+
+// Return the magnetic stripe tracks 1 and 2 of ISO 7813 for a
+// card: the number, holder name, expiry as YYMM, service code 201
+// for chip cards and discretionary data, a PIN verification key
+// index and value and the card verification value
+func (f *Fakery) cardTracks(c *CreditCard) (string, string) {
+	number := stripIDSeparators(c.Number)
+
+	expiry := "0000"
+	if month, year, ok := strings.Cut(c.ExpiryDate, "/"); ok {
+		expiry = year + month
+	}
+	discretionary := f.Numerify("1####") + f.Numerify("###")
+
+	track1 := fmt.Sprintf("%%B%s^%s^%s201%s?", number, trackName(c.Name), expiry, discretionary)
+	track2 := fmt.Sprintf(";%s=%s201%s?", number, expiry, discretionary)
+	return track1, track2
+}
+
+// Return a name as SURNAME/GIVEN NAMES of at most 26 characters
+func trackName(name string) string {
+	name = strings.Map(func(r rune) rune {
+		if (r >= 'A' && r <= 'Z') || r == ' ' {
+			return r
+		}
+		return -1
+	}, strings.ToUpper(name))
+
+	fields := strings.Fields(name)
+	if len(fields) > 1 {
+		name = fields[len(fields)-1] + "/" + strings.Join(fields[:len(fields)-1], " ")
+	} else {
+		name = strings.Join(fields, "")
+	}
+	if len(name) > 26 {
+		name = name[:26]
+	}
+	return name
+}
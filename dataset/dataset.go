@@ -0,0 +1,232 @@
+// Generation of datasets from a schema
+package dataset
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fakery"
+	"fmt"
+	"io"
+)
+
+// A generated dataset, with tables in dependency order
+type Dataset struct {
+	Tables []*Table
+}
+
+// A generated table
+type Table struct {
+	Name    string
+	Columns []string
+	Rows    [][]interface{}
+}
+
+// Return the table with the given name
+func (d *Dataset) Table(name string) *Table {
+	for _, t := range d.Tables {
+		if t.Name == name {
+			return t
+		}
+	}
+	return nil
+}
+
+// Return the index of a column, or -1
+func (t *Table) column(name string) int {
+	for i, c := range t.Columns {
+		if c == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// Generate all tables of the schema. Every column draws on its own
+// stream derived from the seed, table and column names, so adding
+// a column or a table leaves the values of the others unchanged.
+func (s *Schema) Generate() (*Dataset, error) {
+	order, err := s.order()
+	if err != nil {
+		return nil, err
+	}
+
+	root := fakery.NewFromSeed(s.Seed)
+	if s.Locale != "" {
+		root.SetLocale(s.Locale)
+	}
+
+	var d Dataset
+	for _, ts := range order {
+		t, err := s.generateTable(root, ts, &d)
+		if err != nil {
+			return nil, err
+		}
+		d.Tables = append(d.Tables, t)
+	}
+
+	return &d, nil
+}
+
+func (s *Schema) generateTable(root *fakery.Fakery, ts *TableSchema, d *Dataset) (*Table, error) {
+	t := &Table{Name: ts.Name}
+	for _, c := range ts.Columns {
+		t.Columns = append(t.Columns, c.Name)
+	}
+
+	// parents[i] is the parent row index of row i for child tables
+	var parents []int
+	count := ts.Rows
+
+	if ts.Per != "" {
+		f := root.Derive(ts.Name, "rows")
+		parent := d.Table(ts.Per)
+		for p := range parent.Rows {
+			k := f.RandIntBetween(ts.Min, ts.Max+1)
+			for j := 0; j < k; j++ {
+				parents = append(parents, p)
+			}
+		}
+		count = len(parents)
+	}
+
+	t.Rows = make([][]interface{}, count)
+	for i := range t.Rows {
+		t.Rows[i] = make([]interface{}, len(ts.Columns))
+	}
+
+	for col, c := range ts.Columns {
+		e, err := parseExpr(c.Expr)
+		if err != nil {
+			return nil, err
+		}
+
+		f := root.Derive(ts.Name, c.Name)
+		for i := range t.Rows {
+			if e.kind != exprRef {
+				t.Rows[i][col] = e.eval(f, i)
+				continue
+			}
+
+			ref := d.Table(e.refTable)
+			refCol := ref.column(e.refColumn)
+			switch {
+			case e.refTable == ts.Per:
+				// Child rows point at the parent they were made for
+				t.Rows[i][col] = ref.Rows[parents[i]][refCol]
+			case len(ref.Rows) == 0:
+				return nil, fmt.Errorf("table %s, column %s: table %s has no rows", ts.Name, c.Name, ref.Name)
+			default:
+				t.Rows[i][col] = ref.Rows[f.IntRange(len(ref.Rows))][refCol]
+			}
+		}
+	}
+
+	return t, nil
+}
+
+// Order tables so that every table comes after the ones it
+// references, keeping the schema order otherwise
+func (s *Schema) order() ([]*TableSchema, error) {
+	var order []*TableSchema
+	state := make(map[string]int)
+
+	var visit func(t *TableSchema) error
+	visit = func(t *TableSchema) error {
+		switch state[t.Name] {
+		case 1:
+			return fmt.Errorf("error - table %s is part of a reference cycle", t.Name)
+		case 2:
+			return nil
+		}
+
+		state[t.Name] = 1
+		for _, dep := range t.dependencies() {
+			if dep == t.Name {
+				return fmt.Errorf("error - table %s references itself", t.Name)
+			}
+			if err := visit(s.Table(dep)); err != nil {
+				return err
+			}
+		}
+		state[t.Name] = 2
+		order = append(order, t)
+		return nil
+	}
+
+	for _, t := range s.Tables {
+		if err := visit(t); err != nil {
+			return nil, err
+		}
+	}
+	return order, nil
+}
+
+// Write the table as CSV with a header row
+func (t *Table) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+
+	if err := cw.Write(t.Columns); err != nil {
+		return err
+	}
+
+	record := make([]string, len(t.Columns))
+	for _, row := range t.Rows {
+		for i, v := range row {
+			record[i] = fmt.Sprint(v)
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// Write the table as JSON lines, one object per row with the keys
+// in the order of the columns
+func (t *Table) WriteJSONL(w io.Writer) error {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+
+	for _, row := range t.Rows {
+		buf.Reset()
+		buf.WriteByte('{')
+		for i, v := range row {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if err := encoder.Encode(t.Columns[i]); err != nil {
+				return err
+			}
+			buf.Truncate(buf.Len() - 1)
+			buf.WriteByte(':')
+			if err := encoder.Encode(v); err != nil {
+				return err
+			}
+			buf.Truncate(buf.Len() - 1)
+		}
+		buf.WriteString("}\n")
+
+		if _, err := w.Write(buf.Bytes()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Return the rows as maps keyed by column name
+func (t *Table) Maps() []map[string]interface{} {
+	out := make([]map[string]interface{}, len(t.Rows))
+
+	for i, row := range t.Rows {
+		m := make(map[string]interface{}, len(row))
+		for j, v := range row {
+			m[t.Columns[j]] = v
+		}
+		out[i] = m
+	}
+	return out
+}
@@ -0,0 +1,145 @@
+// Column expressions of a dataset schema
+package dataset
+
+import (
+	"fakery"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+type exprKind int
+
+const (
+	// a named fakery generator, e.g: person.name
+	exprGenerator exprKind = iota
+	// seq or seq(start)
+	exprSeq
+	// int(min, max)
+	exprInt
+	// float(min, max)
+	exprFloat
+	// normal(mu, sigma)
+	exprNormal
+	// lognormal(mu, sigma)
+	exprLogNormal
+	// oneof(a, b, c)
+	exprOneOf
+	// ref(table.column)
+	exprRef
+)
+
+// A parsed column expression
+type expr struct {
+	kind exprKind
+	gen  fakery.Generator
+	args []string
+	nums []float64
+
+	refTable  string
+	refColumn string
+}
+
+// Number of numeric arguments of the functions taking them
+var numericArity = map[string]struct {
+	kind  exprKind
+	arity int
+}{
+	"int":       {exprInt, 2},
+	"float":     {exprFloat, 2},
+	"normal":    {exprNormal, 2},
+	"lognormal": {exprLogNormal, 2},
+}
+
+func parseExpr(s string) (*expr, error) {
+	s = strings.TrimSpace(s)
+
+	name, argString, isCall := strings.Cut(s, "(")
+	if !isCall {
+		if s == "seq" {
+			return &expr{kind: exprSeq, nums: []float64{1}}, nil
+		}
+		gen, ok := fakery.LookupGenerator(s)
+		if !ok {
+			return nil, fmt.Errorf("error - unknown generator %q", s)
+		}
+		return &expr{kind: exprGenerator, gen: gen}, nil
+	}
+
+	if !strings.HasSuffix(argString, ")") {
+		return nil, fmt.Errorf("error - missing ')' in %q", s)
+	}
+	argString = strings.TrimSuffix(argString, ")")
+
+	var args []string
+	for _, arg := range strings.Split(argString, ",") {
+		args = append(args, strings.TrimSpace(arg))
+	}
+
+	name = strings.TrimSpace(name)
+	switch name {
+	case "seq":
+		start, err := strconv.ParseFloat(args[0], 64)
+		if err != nil {
+			return nil, fmt.Errorf("error - invalid seq start in %q", s)
+		}
+		return &expr{kind: exprSeq, nums: []float64{start}}, nil
+	case "oneof":
+		return &expr{kind: exprOneOf, args: args}, nil
+	case "ref":
+		table, column, ok := strings.Cut(args[0], ".")
+		if !ok || len(args) != 1 {
+			return nil, fmt.Errorf("error - ref needs table.column in %q", s)
+		}
+		return &expr{kind: exprRef, refTable: table, refColumn: column}, nil
+	}
+
+	fn, ok := numericArity[name]
+	if !ok {
+		return nil, fmt.Errorf("error - unknown function %q", name)
+	}
+	if len(args) != fn.arity {
+		return nil, fmt.Errorf("error - %s takes %d arguments", name, fn.arity)
+	}
+
+	e := &expr{kind: fn.kind}
+	for _, arg := range args {
+		n, err := strconv.ParseFloat(arg, 64)
+		if err != nil {
+			return nil, fmt.Errorf("error - invalid number %q in %q", arg, s)
+		}
+		e.nums = append(e.nums, n)
+	}
+	if (e.kind == exprInt || e.kind == exprFloat) && e.nums[1] < e.nums[0] {
+		return nil, fmt.Errorf("error - %s max %v is below min %v in %q", name, e.nums[1], e.nums[0], s)
+	}
+	return e, nil
+}
+
+// Evaluate an expression for row number i (0 based). Refs are
+// resolved by the generator, not here.
+func (e *expr) eval(f *fakery.Fakery, i int) interface{} {
+	switch e.kind {
+	case exprGenerator:
+		return e.gen(f)
+	case exprSeq:
+		return int(e.nums[0]) + i
+	case exprInt:
+		return f.RandIntBetween(int(e.nums[0]), int(e.nums[1])+1)
+	case exprFloat:
+		return round(e.nums[0] + f.Float64()*(e.nums[1]-e.nums[0]))
+	case exprNormal:
+		return round(e.nums[0] + f.NormFloat64()*e.nums[1])
+	case exprLogNormal:
+		return round(math.Exp(e.nums[0] + f.NormFloat64()*e.nums[1]))
+	case exprOneOf:
+		return fakery.Pick(f, e.args)
+	}
+	return nil
+}
+
+// Amounts are kept to two decimals
+func round(v float64) float64 {
+	return math.Round(v*100) / 100
+}
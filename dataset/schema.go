@@ -0,0 +1,216 @@
+// Declarative, schema-file driven datasets
+package dataset
+
+import (
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Schema describes a dataset of related tables. It is read from
+// YAML or JSON, e.g:
+//
+//	seed: 42
+//	tables:
+//	  users:
+//	    rows: 1000
+//	    columns:
+//	      id: uuid
+//	      name: person.name
+//	      email: internet.email
+//	  orders:
+//	    per: users
+//	    min: 1
+//	    max: 10
+//	    columns:
+//	      id: seq
+//	      user_id: ref(users.id)
+//	      amount: lognormal(3, 1)
+type Schema struct {
+	Seed   int64
+	Locale string
+	Tables []*TableSchema
+}
+
+// Schema of a single table. Either Rows is given, or Per names a
+// parent table and every parent row gets Min..Max child rows. Rows
+// and Max must be at least 1, Min defaults to 0.
+type TableSchema struct {
+	Name    string
+	Rows    int
+	Per     string
+	Min     int
+	Max     int
+	Columns []ColumnSchema
+}
+
+// A column and the expression generating its values
+type ColumnSchema struct {
+	Name string
+	Expr string
+}
+
+// Load a schema from a YAML or JSON file
+func Load(path string) (*Schema, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(data)
+}
+
+// Parse a schema from YAML or JSON (JSON being a subset of YAML)
+func Parse(data []byte) (*Schema, error) {
+	var s Schema
+
+	if err := yaml.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("error - invalid schema: %w", err)
+	}
+
+	if err := s.validate(); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+// Tables and columns are mappings, decoded by hand to keep
+// the order they were written in
+func (s *Schema) UnmarshalYAML(node *yaml.Node) error {
+	var raw struct {
+		Seed   int64     `yaml:"seed"`
+		Locale string    `yaml:"locale"`
+		Tables yaml.Node `yaml:"tables"`
+	}
+
+	if err := node.Decode(&raw); err != nil {
+		return err
+	}
+	s.Seed = raw.Seed
+	s.Locale = raw.Locale
+
+	return eachPair(&raw.Tables, func(key string, value *yaml.Node) error {
+		t := &TableSchema{Name: key}
+		if err := value.Decode(t); err != nil {
+			return fmt.Errorf("table %s: %w", key, err)
+		}
+		s.Tables = append(s.Tables, t)
+		return nil
+	})
+}
+
+func (t *TableSchema) UnmarshalYAML(node *yaml.Node) error {
+	var raw struct {
+		Rows    int       `yaml:"rows"`
+		Per     string    `yaml:"per"`
+		Min     int       `yaml:"min"`
+		Max     int       `yaml:"max"`
+		Columns yaml.Node `yaml:"columns"`
+	}
+
+	if err := node.Decode(&raw); err != nil {
+		return err
+	}
+	t.Rows, t.Per, t.Min, t.Max = raw.Rows, raw.Per, raw.Min, raw.Max
+
+	return eachPair(&raw.Columns, func(key string, value *yaml.Node) error {
+		if value.Kind != yaml.ScalarNode {
+			return fmt.Errorf("column %s: expression must be a string", key)
+		}
+		t.Columns = append(t.Columns, ColumnSchema{Name: key, Expr: value.Value})
+		return nil
+	})
+}
+
+// Call fn for every key/value pair of a mapping node, in order
+func eachPair(node *yaml.Node, fn func(key string, value *yaml.Node) error) error {
+	if node.Kind == 0 {
+		return nil
+	}
+	if node.Kind != yaml.MappingNode {
+		return fmt.Errorf("line %d: expected a mapping", node.Line)
+	}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if err := fn(node.Content[i].Value, node.Content[i+1]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Return the table with the given name
+func (s *Schema) Table(name string) *TableSchema {
+	for _, t := range s.Tables {
+		if t.Name == name {
+			return t
+		}
+	}
+	return nil
+}
+
+func (s *Schema) validate() error {
+	if len(s.Tables) == 0 {
+		return fmt.Errorf("error - schema has no tables")
+	}
+
+	for _, t := range s.Tables {
+		if len(t.Columns) == 0 {
+			return fmt.Errorf("table %s: no columns", t.Name)
+		}
+
+		if t.Per != "" {
+			if s.Table(t.Per) == nil {
+				return fmt.Errorf("table %s: unknown parent table %q", t.Name, t.Per)
+			}
+			if t.Min < 0 || t.Max < 1 || t.Max < t.Min {
+				return fmt.Errorf("table %s: invalid cardinality %d..%d, max must be at least 1", t.Name, t.Min, t.Max)
+			}
+		} else if t.Rows < 1 {
+			return fmt.Errorf("table %s: invalid row count %d, rows must be at least 1", t.Name, t.Rows)
+		}
+
+		for _, c := range t.Columns {
+			e, err := parseExpr(c.Expr)
+			if err != nil {
+				return fmt.Errorf("table %s, column %s: %w", t.Name, c.Name, err)
+			}
+
+			if e.kind == exprRef {
+				ref := s.Table(e.refTable)
+				if ref == nil {
+					return fmt.Errorf("table %s, column %s: unknown table %q", t.Name, c.Name, e.refTable)
+				}
+				if !ref.hasColumn(e.refColumn) {
+					return fmt.Errorf("table %s, column %s: unknown column %q", t.Name, c.Name, c.Expr)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+func (t *TableSchema) hasColumn(name string) bool {
+	for _, c := range t.Columns {
+		if c.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Tables a table depends on through refs or its parent
+func (t *TableSchema) dependencies() []string {
+	var deps []string
+
+	if t.Per != "" {
+		deps = append(deps, t.Per)
+	}
+	for _, c := range t.Columns {
+		if e, err := parseExpr(c.Expr); err == nil && e.kind == exprRef {
+			deps = append(deps, e.refTable)
+		}
+	}
+	return deps
+}
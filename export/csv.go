@@ -0,0 +1,95 @@
+// Export generated records as CSV or TSV
+package export
+
+import (
+	"encoding/csv"
+	"io"
+	"iter"
+)
+
+// CSVWriter streams records as CSV rows. The header is derived
+// from the json tags of the first record and written before it.
+type CSVWriter struct {
+	w             *csv.Writer
+	columns       []string
+	headerWritten bool
+}
+
+// Options for the CSV writer
+type CSVOption func(c *CSVWriter)
+
+// Export only the given columns, in the given order. Nested
+// fields are named with dots, e.g: "address.city".
+func WithColumns(columns ...string) CSVOption {
+	return func(c *CSVWriter) {
+		c.columns = columns
+	}
+}
+
+// Use a different field delimiter
+func WithComma(comma rune) CSVOption {
+	return func(c *CSVWriter) {
+		c.w.Comma = comma
+	}
+}
+
+// Create a CSV writer writing to w
+func NewCSVWriter(w io.Writer, opts ...CSVOption) *CSVWriter {
+	c := &CSVWriter{w: csv.NewWriter(w)}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Create a tab separated writer writing to w
+func NewTSVWriter(w io.Writer, opts ...CSVOption) *CSVWriter {
+	return NewCSVWriter(w, append([]CSVOption{WithComma('\t')}, opts...)...)
+}
+
+// Write a single record as a row
+func (c *CSVWriter) Write(record interface{}) error {
+	columns, err := flatten(record)
+	if err != nil {
+		return err
+	}
+
+	if c.columns == nil {
+		c.columns = columnNames(columns)
+	}
+	if columns, err = selectColumns(columns, c.columns); err != nil {
+		return err
+	}
+
+	if !c.headerWritten {
+		if err = c.w.Write(c.columns); err != nil {
+			return err
+		}
+		c.headerWritten = true
+	}
+
+	row := make([]string, len(columns))
+	for i, col := range columns {
+		row[i] = formatValue(col.Value)
+	}
+
+	return c.w.Write(row)
+}
+
+// Flush buffered rows to the underlying writer
+func (c *CSVWriter) Flush() error {
+	c.w.Flush()
+	return c.w.Error()
+}
+
+// Write every record of a stream and flush. Rows are written
+// as they are produced so large streams are not held in memory.
+func WriteCSV[T any](c *CSVWriter, records iter.Seq[T]) error {
+	for record := range records {
+		if err := c.Write(record); err != nil {
+			return err
+		}
+	}
+	return c.Flush()
+}
@@ -0,0 +1,222 @@
+// Flattening of records into named columns
+package export
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// A single flattened column of a record
+type column struct {
+	Name  string
+	Value reflect.Value
+}
+
+// Return the column name of a struct field from its json tag.
+// Fields tagged "-" and unexported fields are skipped.
+func fieldName(field reflect.StructField) (string, bool) {
+	if !field.IsExported() {
+		return "", false
+	}
+
+	tag := field.Tag.Get("json")
+	if tag == "-" {
+		return "", false
+	}
+
+	name, _, _ := strings.Cut(tag, ",")
+	if name == "" {
+		name = field.Name
+	}
+	return name, true
+}
+
+// Row is a record with dynamic columns, for data which isn't a
+// Go struct such as generated dataset tables. Nil values are
+// exported as empty/NULL.
+type Row struct {
+	Columns []string
+	Values  []interface{}
+}
+
+// Encode the row as a JSON object, keeping the column order
+func (r Row) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+
+	buf.WriteByte('{')
+	for i, name := range r.Columns {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		key, err := json.Marshal(name)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(r.Values[i])
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+
+	return buf.Bytes(), nil
+}
+
+// Flatten a record into columns. Nested structs become dotted
+// column names (e.g: "address.city") while embedded structs
+// without a json name are inlined, as encoding/json does.
+func flatten(record interface{}) ([]column, error) {
+	if row, ok := record.(Row); ok {
+		return flattenRow(row)
+	}
+
+	rv := reflect.ValueOf(record)
+	for rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return nil, fmt.Errorf("error - cannot export nil %T", record)
+		}
+		rv = rv.Elem()
+	}
+
+	if rv.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("error - cannot export %T, need a struct", record)
+	}
+
+	var columns []column
+	flattenStruct(rv, "", &columns, map[reflect.Type]bool{})
+	return columns, nil
+}
+
+func flattenRow(row Row) ([]column, error) {
+	if len(row.Columns) != len(row.Values) {
+		return nil, fmt.Errorf("error - row has %d columns but %d values", len(row.Columns), len(row.Values))
+	}
+
+	columns := make([]column, len(row.Columns))
+	for i, name := range row.Columns {
+		// Addressing the slice element keeps nil values valid
+		columns[i] = column{Name: name, Value: reflect.ValueOf(&row.Values[i]).Elem()}
+	}
+	return columns, nil
+}
+
+// Flatten the fields of a struct into columns. The types being
+// flattened are kept in path, so that a struct nested in itself,
+// e.g: type Node struct{ Next *Node }, becomes a single column.
+func flattenStruct(rv reflect.Value, prefix string, columns *[]column, path map[reflect.Type]bool) {
+	rt := rv.Type()
+	path[rt] = true
+	defer delete(path, rt)
+
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		name, ok := fieldName(field)
+		if !ok {
+			continue
+		}
+
+		value := rv.Field(i)
+		if field.Anonymous && field.Tag.Get("json") == "" && value.Kind() == reflect.Struct && !path[value.Type()] {
+			flattenStruct(value, prefix, columns, path)
+			continue
+		}
+
+		if isNested(value.Type()) && !path[derefType(value.Type())] {
+			// Nil nested pointers still produce their columns
+			if value.Kind() == reflect.Pointer {
+				if value.IsNil() {
+					value = reflect.Zero(value.Type().Elem())
+				} else {
+					value = value.Elem()
+				}
+			}
+			flattenStruct(value, prefix+name+".", columns, path)
+			continue
+		}
+
+		*columns = append(*columns, column{Name: prefix + name, Value: value})
+	}
+}
+
+// Nested structs are flattened, except for time.Time
+func isNested(t reflect.Type) bool {
+	t = derefType(t)
+	return t.Kind() == reflect.Struct && t != reflect.TypeOf(time.Time{})
+}
+
+func derefType(t reflect.Type) reflect.Type {
+	if t.Kind() == reflect.Pointer {
+		return t.Elem()
+	}
+	return t
+}
+
+// Return the names of the columns
+func columnNames(columns []column) []string {
+	names := make([]string, len(columns))
+	for i, c := range columns {
+		names[i] = c.Name
+	}
+	return names
+}
+
+// Pick the named columns, in that order
+func selectColumns(columns []column, names []string) ([]column, error) {
+	byName := make(map[string]column, len(columns))
+	for _, c := range columns {
+		byName[c.Name] = c
+	}
+
+	selected := make([]column, len(names))
+	for i, name := range names {
+		c, ok := byName[name]
+		if !ok {
+			return nil, fmt.Errorf("error - unknown column %q", name)
+		}
+		selected[i] = c
+	}
+
+	return selected, nil
+}
+
+// Format a value as text. Nil pointers format as "".
+func formatValue(v reflect.Value) string {
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return ""
+		}
+		v = v.Elem()
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		return v.String()
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10)
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'f', -1, v.Type().Bits())
+	}
+
+	if t, ok := v.Interface().(time.Time); ok {
+		return t.Format(time.RFC3339)
+	}
+	// Structs nested in themselves are kept whole as JSON
+	if v.Kind() == reflect.Struct {
+		if b, err := json.Marshal(v.Interface()); err == nil {
+			return string(b)
+		}
+	}
+	return fmt.Sprint(v.Interface())
+}
@@ -0,0 +1,370 @@
+// Export generated records as SQL INSERT statements or Postgres COPY data
+package export
+
+import (
+	"fakery"
+	"fmt"
+	"io"
+	"iter"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SQL dialects supported by the SQL writer
+type Dialect int
+
+const (
+	Postgres Dialect = iota
+	MySQL
+	SQLite
+)
+
+func (d Dialect) String() string {
+	switch d {
+	case Postgres:
+		return "postgres"
+	case MySQL:
+		return "mysql"
+	case SQLite:
+		return "sqlite"
+	}
+	return "unknown"
+}
+
+// Return the dialect for a name such as "postgres" or "mysql"
+func ParseDialect(name string) (Dialect, error) {
+	switch strings.ToLower(name) {
+	case "postgres", "postgresql", "pg":
+		return Postgres, nil
+	case "mysql", "mariadb":
+		return MySQL, nil
+	case "sqlite", "sqlite3":
+		return SQLite, nil
+	}
+	return 0, fmt.Errorf("error - unknown SQL dialect %q", name)
+}
+
+// SQLWriter streams records as batched INSERT statements, or as
+// COPY ... FROM STDIN text for Postgres. Column names come from
+// the json tags, with nested names joined by "_".
+type SQLWriter struct {
+	w           io.Writer
+	table       string
+	dialect     Dialect
+	batchSize   int
+	copyMode    bool
+	createTable bool
+	columns     []string
+	// rendered rows of the current batch
+	rows    []string
+	started bool
+}
+
+// Options for the SQL writer
+type SQLOption func(s *SQLWriter)
+
+// Number of rows per INSERT statement, default 100
+func WithBatchSize(n int) SQLOption {
+	return func(s *SQLWriter) {
+		s.batchSize = fakery.MaxInt(1, n)
+	}
+}
+
+// Emit a CREATE TABLE statement before the data
+func WithCreateTable() SQLOption {
+	return func(s *SQLWriter) {
+		s.createTable = true
+	}
+}
+
+// Emit Postgres COPY ... FROM STDIN text instead of INSERTs
+func WithCopy() SQLOption {
+	return func(s *SQLWriter) {
+		s.copyMode = true
+	}
+}
+
+// Export only the given columns, in the given order. Use the
+// dotted names, e.g: "address.city".
+func WithSQLColumns(columns ...string) SQLOption {
+	return func(s *SQLWriter) {
+		s.columns = columns
+	}
+}
+
+// Create a SQL writer for the given table and dialect
+func NewSQLWriter(w io.Writer, table string, dialect Dialect, opts ...SQLOption) *SQLWriter {
+	s := &SQLWriter{w: w, table: table, dialect: dialect, batchSize: 100}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Write a single record. Rows are buffered up to the batch size.
+func (s *SQLWriter) Write(record interface{}) error {
+	columns, err := flatten(record)
+	if err != nil {
+		return err
+	}
+
+	if s.columns == nil {
+		s.columns = columnNames(columns)
+	}
+	if columns, err = selectColumns(columns, s.columns); err != nil {
+		return err
+	}
+
+	if !s.started {
+		if err = s.start(columns); err != nil {
+			return err
+		}
+	}
+
+	values := make([]string, len(columns))
+	for i, col := range columns {
+		if s.copyMode {
+			values[i] = copyValue(col.Value)
+		} else {
+			values[i] = s.literal(col.Value)
+		}
+	}
+
+	if s.copyMode {
+		_, err = io.WriteString(s.w, strings.Join(values, "\t")+"\n")
+		return err
+	}
+
+	s.rows = append(s.rows, "("+strings.Join(values, ", ")+")")
+	if len(s.rows) >= s.batchSize {
+		return s.flushBatch()
+	}
+	return nil
+}
+
+// Write the DDL and the COPY header before the first row
+func (s *SQLWriter) start(columns []column) error {
+	if s.copyMode && s.dialect != Postgres {
+		return fmt.Errorf("error - COPY is only supported for postgres, not %s", s.dialect)
+	}
+
+	s.started = true
+	if s.createTable {
+		if _, err := io.WriteString(s.w, createTable(s.table, s.dialect, columns)+"\n"); err != nil {
+			return err
+		}
+	}
+
+	if s.copyMode {
+		_, err := fmt.Fprintf(s.w, "COPY %s (%s) FROM STDIN;\n", s.quoteIdent(s.table), s.columnList())
+		return err
+	}
+	return nil
+}
+
+func (s *SQLWriter) flushBatch() error {
+	if len(s.rows) == 0 {
+		return nil
+	}
+
+	_, err := fmt.Fprintf(s.w, "INSERT INTO %s (%s) VALUES\n%s;\n",
+		s.quoteIdent(s.table), s.columnList(), strings.Join(s.rows, ",\n"))
+	s.rows = s.rows[:0]
+	return err
+}
+
+// Flush the pending batch, or terminate the COPY data
+func (s *SQLWriter) Flush() error {
+	if s.copyMode {
+		if !s.started {
+			return nil
+		}
+		_, err := io.WriteString(s.w, "\\.\n")
+		return err
+	}
+	return s.flushBatch()
+}
+
+// Write every record of a stream and flush
+func WriteSQL[T any](s *SQLWriter, records iter.Seq[T]) error {
+	for record := range records {
+		if err := s.Write(record); err != nil {
+			return err
+		}
+	}
+	return s.Flush()
+}
+
+func (s *SQLWriter) columnList() string {
+	quoted := make([]string, len(s.columns))
+	for i, name := range s.columns {
+		quoted[i] = s.quoteIdent(sqlName(name))
+	}
+	return strings.Join(quoted, ", ")
+}
+
+func (s *SQLWriter) quoteIdent(name string) string {
+	return quoteIdent(s.dialect, name)
+}
+
+func (s *SQLWriter) literal(v reflect.Value) string {
+	return literal(s.dialect, v)
+}
+
+// Column names can't carry the dots of nested fields
+func sqlName(name string) string {
+	return strings.ReplaceAll(name, ".", "_")
+}
+
+func quoteIdent(d Dialect, name string) string {
+	if d == MySQL {
+		return "`" + strings.ReplaceAll(name, "`", "``") + "`"
+	}
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+func quoteString(d Dialect, s string) string {
+	s = strings.ReplaceAll(s, "'", "''")
+	if d == MySQL {
+		// MySQL treats backslash as an escape in string literals
+		s = strings.ReplaceAll(s, `\`, `\\`)
+	}
+	return "'" + s + "'"
+}
+
+// Render a value as a SQL literal
+func literal(d Dialect, v reflect.Value) string {
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return "NULL"
+		}
+		v = v.Elem()
+	}
+
+	switch v.Kind() {
+	case reflect.Bool:
+		if d == Postgres {
+			return strings.ToUpper(strconv.FormatBool(v.Bool()))
+		}
+		if v.Bool() {
+			return "1"
+		}
+		return "0"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return formatValue(v)
+	case reflect.Float32, reflect.Float64:
+		if !isFinite(v.Float()) {
+			return "NULL"
+		}
+		return formatValue(v)
+	}
+
+	if t, ok := v.Interface().(time.Time); ok {
+		return quoteString(d, t.UTC().Format("2006-01-02 15:04:05"))
+	}
+	return quoteString(d, formatValue(v))
+}
+
+// Render a value in the Postgres COPY text format
+func copyValue(v reflect.Value) string {
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return `\N`
+		}
+		v = v.Elem()
+	}
+
+	if (v.Kind() == reflect.Float32 || v.Kind() == reflect.Float64) && !isFinite(v.Float()) {
+		return `\N`
+	}
+	if t, ok := v.Interface().(time.Time); ok {
+		return t.UTC().Format("2006-01-02 15:04:05")
+	}
+
+	replacer := strings.NewReplacer(`\`, `\\`, "\t", `\t`, "\n", `\n`, "\r", `\r`)
+	return replacer.Replace(formatValue(v))
+}
+
+// NaN and infinities have no literal in every dialect and are
+// written as NULL
+func isFinite(x float64) bool {
+	return !math.IsNaN(x) && !math.IsInf(x, 0)
+}
+
+// Return the SQL column type of a Go type
+func sqlType(d Dialect, t reflect.Type) string {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	if t == reflect.TypeOf(time.Time{}) {
+		switch d {
+		case MySQL:
+			return "DATETIME"
+		case SQLite:
+			return "TEXT"
+		}
+		return "TIMESTAMP"
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		if d == SQLite {
+			return "INTEGER"
+		}
+		return "BOOLEAN"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if d == SQLite {
+			return "INTEGER"
+		}
+		return "BIGINT"
+	case reflect.Float32, reflect.Float64:
+		switch d {
+		case MySQL:
+			return "DOUBLE"
+		case SQLite:
+			return "REAL"
+		}
+		return "DOUBLE PRECISION"
+	}
+	return "TEXT"
+}
+
+func createTable(table string, d Dialect, columns []column) string {
+	defs := make([]string, len(columns))
+
+	for i, col := range columns {
+		t := col.Value.Type()
+		if col.Value.Kind() == reflect.Interface && !col.Value.IsNil() {
+			// Dynamic row values, typed by the first row
+			t = col.Value.Elem().Type()
+		}
+
+		def := quoteIdent(d, sqlName(col.Name)) + " " + sqlType(d, t)
+		// Pointer fields are the nullable ones, and floats which are
+		// written as NULL when not finite
+		switch col.Value.Kind() {
+		case reflect.Pointer, reflect.Interface, reflect.Float32, reflect.Float64:
+		default:
+			def += " NOT NULL"
+		}
+		defs[i] = "  " + def
+	}
+
+	return fmt.Sprintf("CREATE TABLE %s (\n%s\n);", quoteIdent(d, table), strings.Join(defs, ",\n"))
+}
+
+// Return the CREATE TABLE statement matching a record type
+func CreateTable(table string, d Dialect, record interface{}) (string, error) {
+	columns, err := flatten(record)
+	if err != nil {
+		return "", err
+	}
+	return createTable(table, d, columns), nil
+}
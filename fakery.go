@@ -2,12 +2,13 @@
 package fakery
 
 import (
+	"encoding/binary"
 	"encoding/json"
 	"fmt"
+	"hash/fnv"
 	"math"
-	"math/rand"
+	"math/rand/v2"
 	"strings"
-	"time"
 
 	"golang.org/x/text/cases"
 	"golang.org/x/text/language"
@@ -38,7 +39,12 @@ func (b Base) String(v interface{}) string {
 // structures. E.g: Person->Name, Person->Address,
 // Person->Creditcard etc.
 type Fakery struct {
-	rng    *rand.Rand
+	rng *rand.Rand
+	// source backing rng, kept for snapshots
+	src Source
+	// seed the rng was created from, kept so
+	// child instances can be derived from it
+	seed   int64
 	locale string
 	// Cached locale data
 	data *LocaleData
@@ -59,7 +65,7 @@ func (f *Fakery) LoadGenericLocale(l *DataLoader) *LocaleData {
 
 // Return an integer in the interval [0, n)
 func (f *Fakery) IntRange(n int) int {
-	return f.rng.Intn(n)
+	return f.rng.IntN(n)
 }
 
 // Return a random digit in range 0..9
@@ -88,6 +94,17 @@ func (f *Fakery) RandFloat(maxDecimals, x, y int) float64 {
 	return value + decimals
 }
 
+// Return a random float in the interval [0.0, 1.0)
+func (f *Fakery) Float64() float64 {
+	return f.rng.Float64()
+}
+
+// Return a normally distributed float with mean 0 and
+// standard deviation 1
+func (f *Fakery) NormFloat64() float64 {
+	return f.rng.NormFloat64()
+}
+
 // Return a random digit in range 1..9
 func (f *Fakery) RandDigitNonZero() int {
 	return f.IntRange(9) + 1
@@ -143,10 +160,9 @@ func (f *Fakery) RandomWeightedItem(array *WeightedArray) (string, error) {
 	return array.Items[len(array.Items)-1].Item, nil
 }
 
-// Return one of any two strings in a string array
-// Use only when the array has two items
+// Return one of the strings in a string array
 func (f *Fakery) OneOf(choices []string) string {
-	return choices[f.Choice()]
+	return Pick(f, choices)
 }
 
 // Return a random string
@@ -158,19 +174,7 @@ func (f *Fakery) RandomString(stringItems []string) string {
 
 // Return a random string excluding given one
 func (f *Fakery) RandomStringExcl(stringItems []string, excl string) string {
-
-	var idxElem int
-
-	for i := 0; i < len(stringItems); i++ {
-		if stringItems[i] == excl {
-			idxElem = i
-			break
-		}
-	}
-
-	stringItems = append(stringItems[:idxElem], stringItems[idxElem+1:]...)
-	idx := f.IntRange(len(stringItems))
-	return stringItems[idx]
+	return PickExcept(f, stringItems, excl)
 }
 
 // Return a random letter [A-Z]
@@ -260,26 +264,65 @@ func (f *Fakery) SetLocale(locale string) {
 	f.locale = locale
 }
 
-// Fakery constructors
-func New() *Fakery {
-	seed := time.Now().Nanosecond()
+// Return the seed of this instance
+func (f *Fakery) Seed() int64 {
+	return f.seed
+}
+
+// Derive returns a child Fakery whose random stream is a stable
+// function of this instance's seed and the given keys. The parent's
+// stream is not consumed, so f.Derive("orders", 17) yields the same
+// values no matter how many other values were generated before it.
+func (f *Fakery) Derive(keys ...interface{}) *Fakery {
+	h := fnv.New64a()
+
+	var buf [8]byte
+	binary.BigEndian.PutUint64(buf[:], uint64(f.seed))
+	h.Write(buf[:])
+
+	for _, key := range keys {
+		// separator so that ("ab", "c") and ("a", "bc") differ
+		h.Write([]byte{0})
+		fmt.Fprintf(h, "%T:%v", key, key)
+	}
+
+	child := newFakery(int64(h.Sum64()))
+	child.locale = f.locale
+	return child
+}
+
+func newFakery(seed int64) *Fakery {
+	src := NewPCGSource(seed)
 	return &Fakery{
-		rng:    rand.New(rand.NewSource(int64(seed))),
+		rng:    rand.New(src),
+		src:    src,
+		seed:   seed,
 		locale: DefaultLocale,
 	}
 }
 
+// Fakery constructors
+func New() *Fakery {
+	return newFakery(entropySeed())
+}
+
 func NewFromLocale(locale string) *Fakery {
-	seed := time.Now().Nanosecond()
-	return &Fakery{
-		rng:    rand.New(rand.NewSource(int64(seed))),
-		locale: locale,
-	}
+	f := newFakery(entropySeed())
+	f.locale = locale
+	return f
 }
 
 func NewFromSeed(seed int64) *Fakery {
+	return newFakery(seed)
+}
+
+// Create a Fakery drawing from the given random source. One
+// value is read from the source to seed derived instances.
+func NewWithSource(src Source) *Fakery {
 	return &Fakery{
-		rng:    rand.New(rand.NewSource(int64(seed))),
+		rng:    rand.New(src),
+		src:    src,
+		seed:   int64(src.Uint64()),
 		locale: DefaultLocale,
 	}
 }
@@ -0,0 +1,304 @@
+// Populate Protocol Buffers messages with fake data
+package fakerypb
+
+import (
+	"fakery"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Options for Fill
+type Options struct {
+	// Depth up to which nested messages are filled, which ends
+	// recursive message types. Default 4.
+	MaxDepth int
+	// Range of the number of repeated and map entries. Default 1..3.
+	MinRepeated int
+	MaxRepeated int
+	// Generator names by field name, overriding the defaults
+	// picked from the field names, e.g: {"handle": "internet.user_name"}
+	Generators map[string]string
+}
+
+func (o *Options) withDefaults() Options {
+	var opts Options
+	if o != nil {
+		opts = *o
+	}
+
+	if opts.MaxDepth <= 0 {
+		opts.MaxDepth = 4
+	}
+	if opts.MaxRepeated <= 0 {
+		opts.MinRepeated, opts.MaxRepeated = 1, 3
+	}
+	opts.MaxRepeated = fakery.MaxInt(opts.MinRepeated, opts.MaxRepeated)
+
+	return opts
+}
+
+type filler struct {
+	f    *fakery.Fakery
+	opts Options
+}
+
+// Fill sets the fields of msg with fake values. Field names such as
+// email, first_name or postal_code get values of the matching
+// fakery generators. Of every oneof a single field is set. opts
+// may be nil.
+func Fill(f *fakery.Fakery, msg proto.Message, opts *Options) error {
+	fl := &filler{f: f, opts: opts.withDefaults()}
+	return fl.message(msg.ProtoReflect(), "", 0)
+}
+
+// Fill a message. hint is the name of the field holding it, which
+// picks the generator for the wrapper types such as StringValue.
+func (fl *filler) message(m protoreflect.Message, hint string, depth int) error {
+	md := m.Descriptor()
+
+	if fill, ok := wellKnownTypes[md.FullName()]; ok {
+		fill(fl.f, m)
+		return nil
+	}
+	if isWrapper(md) {
+		fd := md.Fields().ByName("value")
+		m.Set(fd, fl.scalar(fd, hint))
+		return nil
+	}
+
+	// One field is chosen per real oneof
+	chosen := map[protoreflect.FullName]protoreflect.FieldDescriptor{}
+	oneofs := md.Oneofs()
+	for i := 0; i < oneofs.Len(); i++ {
+		od := oneofs.Get(i)
+		if od.IsSynthetic() || od.Fields().Len() == 0 {
+			continue
+		}
+		chosen[od.FullName()] = od.Fields().Get(fl.f.IntRange(od.Fields().Len()))
+	}
+
+	fields := md.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+
+		if od := fd.ContainingOneof(); od != nil && !od.IsSynthetic() && chosen[od.FullName()] != fd {
+			continue
+		}
+		if fd.Kind() == protoreflect.MessageKind || fd.Kind() == protoreflect.GroupKind {
+			// Past the depth limit nested messages are left unset
+			if depth >= fl.opts.MaxDepth {
+				continue
+			}
+		}
+
+		if err := fl.field(m, fd, depth); err != nil {
+			return fmt.Errorf("%s: %w", fd.FullName(), err)
+		}
+	}
+
+	return nil
+}
+
+func (fl *filler) count() int {
+	return fl.f.RandIntBetween(fl.opts.MinRepeated, fl.opts.MaxRepeated+1)
+}
+
+func (fl *filler) field(m protoreflect.Message, fd protoreflect.FieldDescriptor, depth int) error {
+	hint := string(fd.Name())
+
+	switch {
+	case fd.IsList():
+		list := m.Mutable(fd).List()
+		for i := fl.count(); i > 0; i-- {
+			if fd.Message() != nil {
+				v := list.NewElement()
+				if err := fl.message(v.Message(), hint, depth+1); err != nil {
+					return err
+				}
+				list.Append(v)
+				continue
+			}
+			list.Append(fl.scalar(fd, hint))
+		}
+	case fd.IsMap():
+		mp := m.Mutable(fd).Map()
+		for i := fl.count(); i > 0; i-- {
+			key := fl.scalar(fd.MapKey(), "").MapKey()
+			if fd.MapValue().Message() != nil {
+				v := mp.NewValue()
+				if err := fl.message(v.Message(), hint, depth+1); err != nil {
+					return err
+				}
+				mp.Set(key, v)
+				continue
+			}
+			mp.Set(key, fl.scalar(fd.MapValue(), hint))
+		}
+	case fd.Message() != nil:
+		return fl.message(m.Mutable(fd).Message(), hint, depth+1)
+	default:
+		m.Set(fd, fl.scalar(fd, hint))
+	}
+
+	return nil
+}
+
+// Return a value for a scalar or enum field. String values are
+// picked by the field name hint.
+func (fl *filler) scalar(fd protoreflect.FieldDescriptor, hint string) protoreflect.Value {
+	f := fl.f
+
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		return protoreflect.ValueOfBool(f.Choice() == 1)
+	case protoreflect.EnumKind:
+		return protoreflect.ValueOfEnum(fl.enum(fd.Enum()))
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		return protoreflect.ValueOfInt32(int32(f.IntRange(10000)))
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return protoreflect.ValueOfInt64(int64(f.IntRange(1000000)))
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		return protoreflect.ValueOfUint32(uint32(f.IntRange(10000)))
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return protoreflect.ValueOfUint64(uint64(f.IntRange(1000000)))
+	case protoreflect.FloatKind:
+		return protoreflect.ValueOfFloat32(float32(math.Round(f.Float64()*100000) / 100))
+	case protoreflect.DoubleKind:
+		return protoreflect.ValueOfFloat64(math.Round(f.Float64()*100000) / 100)
+	case protoreflect.BytesKind:
+		b := make([]byte, f.RandIntBetween(4, 17))
+		for i := range b {
+			b[i] = byte(f.IntRange(256))
+		}
+		return protoreflect.ValueOfBytes(b)
+	}
+
+	return protoreflect.ValueOfString(fl.str(hint))
+}
+
+// Pick a random enum value, avoiding the zero "unspecified"
+// value when there are others
+func (fl *filler) enum(ed protoreflect.EnumDescriptor) protoreflect.EnumNumber {
+	values := ed.Values()
+	if values.Len() == 1 {
+		return values.Get(0).Number()
+	}
+
+	var candidates []protoreflect.EnumNumber
+	for i := 0; i < values.Len(); i++ {
+		if n := values.Get(i).Number(); n != 0 {
+			candidates = append(candidates, n)
+		}
+	}
+	// Only aliases of zero, which is the value left
+	if len(candidates) == 0 {
+		return 0
+	}
+	return fakery.Pick(fl.f, candidates)
+}
+
+func (fl *filler) str(name string) string {
+	if gen, ok := fl.opts.Generators[name]; ok {
+		if v, ok := fl.f.Generate(gen); ok {
+			return fmt.Sprint(v)
+		}
+	}
+	if gen, ok := fieldGenerator(name); ok {
+		if v, ok := fl.f.Generate(gen); ok {
+			return fmt.Sprint(v)
+		}
+	}
+	return fl.f.Adjective()
+}
+
+// Generators for well known field names, keyed by the lowercased
+// name with separators removed
+var fieldGenerators = map[string]string{
+	"name":          "person.name",
+	"fullname":      "person.name",
+	"firstname":     "person.first_name",
+	"givenname":     "person.first_name",
+	"lastname":      "person.last_name",
+	"surname":       "person.last_name",
+	"familyname":    "person.last_name",
+	"gender":        "person.gender",
+	"birthdate":     "person.birthdate",
+	"dateofbirth":   "person.birthdate",
+	"dob":           "person.birthdate",
+	"nationalid":    "person.national_id",
+	"ssn":           "national_id.ssn",
+	"username":      "internet.user_name",
+	"email":         "internet.email",
+	"emailaddress":  "internet.email",
+	"jobtitle":      "job.title",
+	"job":           "job.title",
+	"city":          "address.city",
+	"state":         "address.state",
+	"country":       "address.country",
+	"countrycode":   "address.country_code",
+	"street":        "address.street_address",
+	"streetaddress": "address.street_address",
+	"address":       "address.street_address",
+	"zip":           "address.zip_code",
+	"zipcode":       "address.zip_code",
+	"postalcode":    "address.post_code",
+	"postcode":      "address.post_code",
+	"isbn":          "book.isbn",
+	"title":         "book.title",
+	"author":        "book.author",
+	"publisher":     "book.publisher",
+	"genre":         "book.genre",
+	"color":         "color.safe_name",
+	"currency":      "currency.code",
+	"currencycode":  "currency.code",
+	"useragent":     "user_agent",
+	"url":           "internet.url",
+	"website":       "internet.url",
+	"domain":        "internet.domain_name",
+	"ip":            "internet.ipv4",
+	"ipaddress":     "internet.ipv4",
+	"uuid":          "internet.uuid",
+	"id":            "internet.uuid",
+}
+
+// Return the generator name for a field name such as "firstName",
+// "first_name" or "postal-code"
+func fieldGenerator(field string) (string, bool) {
+	name, ok := fieldGenerators[strings.ToLower(strings.NewReplacer("_", "", "-", "", " ", "").Replace(field))]
+	return name, ok
+}
+
+// Timestamps are drawn before this date rather than now
+var referenceTime = time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// Fillers for the well known types, which carry meaning beyond
+// their fields
+var wellKnownTypes = map[protoreflect.FullName]func(f *fakery.Fakery, m protoreflect.Message){
+	"google.protobuf.Timestamp": func(f *fakery.Fakery, m protoreflect.Message) {
+		// Within the five years before a fixed date, so that seeded
+		// messages do not change over time
+		t := referenceTime.Add(-time.Duration(f.IntRange(5*365*24*3600)) * time.Second)
+		setField(m, "seconds", protoreflect.ValueOfInt64(t.Unix()))
+		setField(m, "nanos", protoreflect.ValueOfInt32(int32(f.IntRange(1e9))))
+	},
+	"google.protobuf.Duration": func(f *fakery.Fakery, m protoreflect.Message) {
+		setField(m, "seconds", protoreflect.ValueOfInt64(int64(f.IntRange(24*3600))))
+		setField(m, "nanos", protoreflect.ValueOfInt32(int32(f.IntRange(1e9))))
+	},
+}
+
+// The wrappers such as google.protobuf.StringValue
+func isWrapper(md protoreflect.MessageDescriptor) bool {
+	return md.ParentFile().Package() == "google.protobuf" &&
+		strings.HasSuffix(string(md.Name()), "Value") &&
+		md.Fields().Len() == 1 && md.Fields().Get(0).Name() == "value"
+}
+
+func setField(m protoreflect.Message, name protoreflect.Name, v protoreflect.Value) {
+	m.Set(m.Descriptor().Fields().ByName(name), v)
+}
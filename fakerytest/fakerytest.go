@@ -0,0 +1,101 @@
+// Helpers for using fakery in tests with reproducible seeds
+//
+// A test gets its generator from New. When the test fails, the seed
+// is logged and the failure can be reproduced with
+//
+//	go test -run TestName -fakery.seed=<seed>
+//
+// or by setting the FAKERY_SEED environment variable.
+package fakerytest
+
+import (
+	"fakery"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"sync"
+)
+
+// Environment variables read when the flags are not given
+const (
+	SeedEnv   = "FAKERY_SEED"
+	UpdateEnv = "FAKERY_UPDATE"
+)
+
+var (
+	seedFlag   = flag.String("fakery.seed", "", "seed for fakery generators in tests (default random)")
+	updateFlag = flag.Bool("fakery.update", false, "rewrite fakery golden files")
+)
+
+var (
+	seedOnce sync.Once
+	seed     int64
+	seedErr  error
+)
+
+// The subset of testing.TB used here
+type TB interface {
+	Helper()
+	Name() string
+	Failed() bool
+	Logf(format string, args ...interface{})
+	Fatalf(format string, args ...interface{})
+	Cleanup(func())
+}
+
+// Return the seed of this test run: from -fakery.seed, from
+// $FAKERY_SEED or else a random one. It is the same for all
+// tests of a run.
+func Seed() (int64, error) {
+	seedOnce.Do(func() {
+		value := *seedFlag
+		if value == "" {
+			value = os.Getenv(SeedEnv)
+		}
+
+		if value == "" {
+			seed = fakery.New().Seed()
+			return
+		}
+
+		seed, seedErr = strconv.ParseInt(value, 10, 64)
+		if seedErr != nil {
+			seedErr = fmt.Errorf("invalid fakery seed %q: %w", value, seedErr)
+		}
+	})
+
+	return seed, seedErr
+}
+
+// Return a generator for the test. It is derived from the run's seed
+// and the test's name, so every test and subtest has its own stream
+// and reproduces alone, whatever other tests run. The seed is logged
+// only if the test fails.
+func New(t TB) *fakery.Fakery {
+	t.Helper()
+
+	s, err := Seed()
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	t.Cleanup(func() {
+		if t.Failed() {
+			t.Logf("fakery seed: %d, reproduce with -fakery.seed=%d or %s=%d", s, s, SeedEnv, s)
+		}
+	})
+
+	return fakery.NewFromSeed(s).Derive(t.Name())
+}
+
+// Return a generator for the test with a fixed seed, e.g. for
+// golden files which must not change between runs
+func NewFromSeed(t TB, seed int64) *fakery.Fakery {
+	return fakery.NewFromSeed(seed).Derive(t.Name())
+}
+
+// Whether golden files should be rewritten
+func update() bool {
+	return *updateFlag || os.Getenv(UpdateEnv) != ""
+}
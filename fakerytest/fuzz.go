@@ -0,0 +1,36 @@
+// Adapters for native Go fuzzing
+package fakerytest
+
+import (
+	"encoding/binary"
+	"fakery"
+	"hash/fnv"
+	"testing"
+)
+
+// Return a Fakery seeded from fuzzer input. Any byte string is a
+// valid seed, so the fuzzer mutates the seed rather than raw data.
+func FromBytes(data []byte) *fakery.Fakery {
+	h := fnv.New64a()
+	h.Write(data)
+	return fakery.NewFromSeed(int64(h.Sum64()))
+}
+
+// Run a fuzz target receiving a seeded Fakery, e.g:
+//
+//	func FuzzParse(f *testing.F) {
+//		fakerytest.Fuzz(f, func(t *testing.T, fk *fakery.Fakery) {
+//			p := fk.Person()
+//			...
+//		})
+//	}
+func Fuzz(f *testing.F, fn func(t *testing.T, fk *fakery.Fakery)) {
+	// A small seed corpus so plain go test runs exercise the target
+	for i := uint64(0); i < 8; i++ {
+		f.Add(binary.BigEndian.AppendUint64(nil, i))
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		fn(t, FromBytes(data))
+	})
+}
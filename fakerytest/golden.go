@@ -0,0 +1,52 @@
+// Golden file helpers for generated fixtures
+package fakerytest
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+)
+
+// Directory holding the golden files, relative to the test's package
+var GoldenDir = "testdata"
+
+// Compare got with the golden file testdata/<name>.golden. With
+// -fakery.update or $FAKERY_UPDATE set the file is rewritten.
+func Golden(t TB, name string, got []byte) {
+	t.Helper()
+
+	path := filepath.Join(GoldenDir, name+".golden")
+	if update() {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("creating golden dir: %v", err)
+		}
+		if err := os.WriteFile(path, got, 0o644); err != nil {
+			t.Fatalf("writing golden file: %v", err)
+		}
+		return
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("reading golden file (run with -fakery.update to create it): %v", err)
+	}
+	if !bytes.Equal(want, got) {
+		t.Fatalf("%s differs from the golden file (run with -fakery.update to accept)\n--- want\n%s\n--- got\n%s", path, want, got)
+	}
+}
+
+// Compare the indented JSON encoding of v with a golden file
+func GoldenJSON(t TB, name string, v interface{}) {
+	t.Helper()
+
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(v); err != nil {
+		t.Fatalf("encoding %T: %v", v, err)
+	}
+
+	Golden(t, name, buf.Bytes())
+}
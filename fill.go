@@ -0,0 +1,178 @@
+// Populate structs from `fake` struct tags
+package fakery
+
+import (
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Parsed form of a `fake:"name,null=0.1"` tag
+type fakeTag struct {
+	name     string
+	nullProb float64
+}
+
+func parseFakeTag(tag string) (fakeTag, error) {
+	var t fakeTag
+
+	parts := strings.Split(tag, ",")
+	t.name = strings.TrimSpace(parts[0])
+
+	for _, opt := range parts[1:] {
+		key, val, _ := strings.Cut(strings.TrimSpace(opt), "=")
+		switch key {
+		case "null":
+			p, err := strconv.ParseFloat(val, 64)
+			if err != nil || p < 0 || p > 1 {
+				return t, fmt.Errorf("error - invalid null probability %q", val)
+			}
+			t.nullProb = p
+		default:
+			return t, fmt.Errorf("error - unknown tag option %q", key)
+		}
+	}
+
+	return t, nil
+}
+
+// Fill populates the fields of the struct pointed to by v using
+// their `fake` tags. The tag names a generator (see Generators)
+// and may carry a null probability, e.g:
+//
+//	Email *string `fake:"email,null=0.1"`
+//
+// Nullable fields are pointers, sql.NullString and sql.NullInt64;
+// a null is left as the zero value. Untagged struct fields are
+// filled recursively and `fake:"-"` skips a field.
+func (f *Fakery) Fill(v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("error - Fill needs a pointer to a struct, got %T", v)
+	}
+	return f.fillStruct(rv.Elem())
+}
+
+func (f *Fakery) fillStruct(rv reflect.Value) error {
+	rt := rv.Type()
+
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		tag, ok := field.Tag.Lookup("fake")
+		if tag == "-" {
+			continue
+		}
+		if !ok {
+			if field.Type.Kind() == reflect.Struct {
+				if err := f.fillStruct(rv.Field(i)); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+
+		t, err := parseFakeTag(tag)
+		if err != nil {
+			return fmt.Errorf("field %s: %w", field.Name, err)
+		}
+
+		gen, ok := LookupGenerator(t.name)
+		if !ok {
+			return fmt.Errorf("field %s: error - unknown generator %q", field.Name, t.name)
+		}
+
+		// Reject the tag whether or not this draw comes up null
+		if t.nullProb > 0 && !isNullable(field.Type) {
+			return fmt.Errorf("field %s: error - type %s is not nullable", field.Name, field.Type)
+		}
+		if t.nullProb > 0 && f.Chance(t.nullProb) {
+			rv.Field(i).SetZero()
+			continue
+		}
+
+		if err := setValue(rv.Field(i), gen(f)); err != nil {
+			return fmt.Errorf("field %s: %w", field.Name, err)
+		}
+	}
+
+	return nil
+}
+
+func isNullable(t reflect.Type) bool {
+	switch t {
+	case reflect.TypeOf(sql.NullString{}), reflect.TypeOf(sql.NullInt64{}):
+		return true
+	}
+	switch t.Kind() {
+	case reflect.Pointer, reflect.Interface, reflect.Slice, reflect.Map:
+		return true
+	}
+	return false
+}
+
+// Assign a generated value to a field, converting where sensible
+func setValue(field reflect.Value, val interface{}) error {
+	switch field.Addr().Interface().(type) {
+	case *sql.NullString:
+		field.Set(reflect.ValueOf(sql.NullString{String: fmt.Sprint(val), Valid: true}))
+		return nil
+	case *sql.NullInt64:
+		n, err := strconv.ParseInt(fmt.Sprint(val), 10, 64)
+		if err != nil {
+			return err
+		}
+		field.Set(reflect.ValueOf(sql.NullInt64{Int64: n, Valid: true}))
+		return nil
+	}
+
+	if field.Kind() == reflect.Pointer {
+		// Composite generators already return pointers
+		rv := reflect.ValueOf(val)
+		if rv.Type().AssignableTo(field.Type()) {
+			field.Set(rv)
+			return nil
+		}
+		ptr := reflect.New(field.Type().Elem())
+		if err := setValue(ptr.Elem(), val); err != nil {
+			return err
+		}
+		field.Set(ptr)
+		return nil
+	}
+
+	rv := reflect.ValueOf(val)
+	if rv.Kind() == reflect.Pointer && rv.Type().Elem().AssignableTo(field.Type()) {
+		field.Set(rv.Elem())
+		return nil
+	}
+
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(fmt.Sprint(val))
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(fmt.Sprint(val), 10, 64)
+		if err != nil {
+			return err
+		}
+		field.SetInt(n)
+	case reflect.Float32, reflect.Float64:
+		n, err := strconv.ParseFloat(fmt.Sprint(val), 64)
+		if err != nil {
+			return err
+		}
+		field.SetFloat(n)
+	default:
+		if !rv.Type().AssignableTo(field.Type()) {
+			return fmt.Errorf("error - cannot assign %T to %s", val, field.Type())
+		}
+		field.Set(rv)
+	}
+
+	return nil
+}
@@ -0,0 +1,215 @@
+// Registry of named generators so that values can be
+// requested by name, e.g. from struct tags.
+package fakery
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// A named generator returning a single fake value
+type Generator func(f *Fakery) interface{}
+
+// Generators are named "<domain>.<field>". Composite
+// records are registered under the bare domain name.
+var generators = map[string]Generator{
+	// person
+	"person":             func(f *Fakery) interface{} { return f.Person() },
+	"person.name":        func(f *Fakery) interface{} { return f.Name() },
+	"person.first_name":  func(f *Fakery) interface{} { return f.FirstName() },
+	"person.last_name":   func(f *Fakery) interface{} { return f.LastName() },
+	"person.gender":      func(f *Fakery) interface{} { return string(f.Gender()) },
+	"person.birthdate":   func(f *Fakery) interface{} { return f.birthdate(minPersonAge, maxPersonAge, ageReferenceDate) },
+	"person.national_id": func(f *Fakery) interface{} { return f.PersonWith().NationalID },
+	"job":                func(f *Fakery) interface{} { return f.Job() },
+	"job.title":          func(f *Fakery) interface{} { return f.Job().Title },
+	// documents
+	"passport":               func(f *Fakery) interface{} { return f.Passport() },
+	"passport.number":        func(f *Fakery) interface{} { return f.Passport().Number },
+	"passport.mrz":           func(f *Fakery) interface{} { return strings.Join(f.Passport().MRZ, "\n") },
+	"id_card":                func(f *Fakery) interface{} { return f.IDCard() },
+	"visa":                   func(f *Fakery) interface{} { return f.Visa() },
+	"drivers_license":        func(f *Fakery) interface{} { return f.DriversLicense() },
+	"drivers_license.number": func(f *Fakery) interface{} { return f.DriversLicense().Number },
+	// internet
+	"internet.email":             func(f *Fakery) interface{} { return f.Email() },
+	"internet.user_name":         func(f *Fakery) interface{} { return f.UserName() },
+	"internet.tld":               func(f *Fakery) interface{} { return f.TLD() },
+	"internet.email_domain":      func(f *Fakery) interface{} { return f.EmailDomain() },
+	"internet.free_email_domain": func(f *Fakery) interface{} { return f.FreeEmailDomain() },
+	"internet.uuid":              func(f *Fakery) interface{} { return f.UUID() },
+	"internet.ipv4":              func(f *Fakery) interface{} { return f.IPv4() },
+	"internet.ipv6":              func(f *Fakery) interface{} { return f.IPv6() },
+	"internet.domain_name":       func(f *Fakery) interface{} { return f.DomainName() },
+	"internet.url":               func(f *Fakery) interface{} { return f.URL() },
+	// address
+	"address":                 func(f *Fakery) interface{} { return f.Address() },
+	"address.city":            func(f *Fakery) interface{} { return f.City() },
+	"address.building_number": func(f *Fakery) interface{} { return f.BuildingNumber() },
+	"address.building_name":   func(f *Fakery) interface{} { return f.BuildingName() },
+	"address.street_name":     func(f *Fakery) interface{} { return f.StreetName() },
+	"address.street_address":  func(f *Fakery) interface{} { return f.StreetAddress() },
+	"address.state":           func(f *Fakery) interface{} { return f.State() },
+	"address.state_abbr":      func(f *Fakery) interface{} { return f.StateAbbr() },
+	"address.post_code":       func(f *Fakery) interface{} { return f.PostCode() },
+	"address.zip_code":        func(f *Fakery) interface{} { return f.ZipCode() },
+	"address.country":         func(f *Fakery) interface{} { return f.Country() },
+	"address.country_code":    func(f *Fakery) interface{} { return f.CountryCode() },
+	// beer
+	"beer":         func(f *Fakery) interface{} { return f.Beer() },
+	"beer.name":    func(f *Fakery) interface{} { return f.BeerName() },
+	"beer.style":   func(f *Fakery) interface{} { return f.BeerStyle() },
+	"beer.hops":    func(f *Fakery) interface{} { return f.BeerHops() },
+	"beer.malt":    func(f *Fakery) interface{} { return f.BeerMalt() },
+	"beer.alcohol": func(f *Fakery) interface{} { return f.BeerAlcohol() },
+	"beer.ibu":     func(f *Fakery) interface{} { return f.BeerIbu() },
+	"beer.blg":     func(f *Fakery) interface{} { return f.BeerBlg() },
+	// blood
+	"blood":      func(f *Fakery) interface{} { return f.Blood() },
+	"blood.type": func(f *Fakery) interface{} { return f.BloodType() },
+	// book
+	"book":           func(f *Fakery) interface{} { return f.Book() },
+	"book.title":     func(f *Fakery) interface{} { return f.BookTitle() },
+	"book.author":    func(f *Fakery) interface{} { return f.BookAuthor() },
+	"book.publisher": func(f *Fakery) interface{} { return f.BookPublisher() },
+	"book.genre":     func(f *Fakery) interface{} { return f.BookGenre() },
+	"book.format":    func(f *Fakery) interface{} { return f.BookFormat() },
+	"book.year":      func(f *Fakery) interface{} { return f.BookYear() },
+	"book.isbn":      func(f *Fakery) interface{} { return f.BookISBN() },
+	// car
+	"car":              func(f *Fakery) interface{} { return f.Car() },
+	"car.make":         func(f *Fakery) interface{} { return f.CarMake() },
+	"car.model":        func(f *Fakery) interface{} { return f.CarModel() },
+	"car.category":     func(f *Fakery) interface{} { return f.CarCategory() },
+	"car.series":       func(f *Fakery) interface{} { return f.CarSeries() },
+	"car.type":         func(f *Fakery) interface{} { return f.CarType() },
+	"car.transmission": func(f *Fakery) interface{} { return f.CarTransmission() },
+	"car.plate":        func(f *Fakery) interface{} { return f.CarPlate() },
+	// color
+	"color":           func(f *Fakery) interface{} { return f.Color() },
+	"color.name":      func(f *Fakery) interface{} { return f.ColorName() },
+	"color.safe_name": func(f *Fakery) interface{} { return f.SafeColorName() },
+	"color.hex":       func(f *Fakery) interface{} { return f.HexColor() },
+	"color.rgb":       func(f *Fakery) interface{} { return f.RGBColor() },
+	"color.hsl":       func(f *Fakery) interface{} { return f.HSLColor() },
+	// credit card
+	"credit_card":        func(f *Fakery) interface{} { return f.CreditCard() },
+	"credit_card.type":   func(f *Fakery) interface{} { return f.CreditCardType() },
+	"credit_card.number": func(f *Fakery) interface{} { return f.CreditCardNumber(f.CreditCardType()) },
+	"credit_card.formatted_number": func(f *Fakery) interface{} {
+		return FormatCardNumber(f.CreditCardNumber(f.CreditCardType()))
+	},
+	"credit_card.expiry_date": func(f *Fakery) interface{} { return f.CreditCardExpiryDate() },
+	"credit_card.cvv":         func(f *Fakery) interface{} { return f.CreditCardCVV(f.CreditCardType()) },
+	// currency
+	"currency":         func(f *Fakery) interface{} { return f.Currency() },
+	"currency.code":    func(f *Fakery) interface{} { return f.CurrencyCode() },
+	"currency.name":    func(f *Fakery) interface{} { return f.CurrencyName() },
+	"currency.country": func(f *Fakery) interface{} { return f.CurrencyCountry() },
+	// emoji
+	"emoji":             func(f *Fakery) interface{} { return f.Emoji() },
+	"emoji.symbol":      func(f *Fakery) interface{} { return f.EmojiSymbol() },
+	"emoji.category":    func(f *Fakery) interface{} { return f.EmojiCategory() },
+	"emoji.description": func(f *Fakery) interface{} { return f.EmojiDescription() },
+	"emoji.alias":       func(f *Fakery) interface{} { return f.EmojiAlias() },
+	// user agent
+	"user_agent":         func(f *Fakery) interface{} { return f.UserAgent() },
+	"user_agent.chrome":  func(f *Fakery) interface{} { return f.Chrome() },
+	"user_agent.firefox": func(f *Fakery) interface{} { return f.Firefox() },
+	"user_agent.safari":  func(f *Fakery) interface{} { return f.Safari() },
+	"user_agent.edge":    func(f *Fakery) interface{} { return f.Edge() },
+	"user_agent.opera":   func(f *Fakery) interface{} { return f.Opera() },
+	"user_agent.ie":      func(f *Fakery) interface{} { return f.IE() },
+	"user_agent.platform": func(f *Fakery) interface{} {
+		return f.PlatformVersion()
+	},
+	// wine
+	"wine":           func(f *Fakery) interface{} { return f.Wine() },
+	"wine.name":      func(f *Fakery) interface{} { return f.WineName() },
+	"wine.varietal":  func(f *Fakery) interface{} { return f.WineVarietal() },
+	"wine.region":    func(f *Fakery) interface{} { return f.WineRegion() },
+	"wine.body":      func(f *Fakery) interface{} { return f.WineBody() },
+	"wine.acidity":   func(f *Fakery) interface{} { return f.WineAcidity() },
+	"wine.tannins":   func(f *Fakery) interface{} { return f.WineTannins() },
+	"wine.sweetness": func(f *Fakery) interface{} { return f.WineSweetness() },
+	"wine.vintage":   func(f *Fakery) interface{} { return f.WineVintage() },
+	"wine.alcohol":   func(f *Fakery) interface{} { return f.WineAlcohol() },
+	// words
+	"word.adjective":          func(f *Fakery) interface{} { return f.Adjective() },
+	"word.adjective_positive": func(f *Fakery) interface{} { return f.AdjectivePositive() },
+	"word.adjective_negative": func(f *Fakery) interface{} { return f.AdjectiveNegative() },
+	"word.adverb":             func(f *Fakery) interface{} { return f.Adverb() },
+}
+
+// Register a named generator, replacing any existing one
+func Register(name string, gen Generator) {
+	generators[name] = gen
+}
+
+// Return the generator for the given name. Besides the full
+// "<domain>.<field>" name, a bare field name such as "email"
+// is accepted as long as it is not ambiguous.
+func LookupGenerator(name string) (Generator, bool) {
+	if gen, ok := generators[name]; ok {
+		return gen, true
+	}
+
+	var found Generator
+	var count int
+
+	for key, gen := range generators {
+		if strings.HasSuffix(key, "."+name) {
+			found = gen
+			count++
+		}
+	}
+
+	return found, count == 1
+}
+
+// Return the sorted names of all registered generators
+func Generators() []string {
+	var names []string
+
+	for name := range generators {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// Generate a value by generator name
+func (f *Fakery) Generate(name string) (interface{}, bool) {
+	gen, ok := LookupGenerator(name)
+	if !ok {
+		return nil, false
+	}
+	return gen(f), true
+}
+
+var placeholderRe = regexp.MustCompile(`{{\s*([\w.]+)\s*}}`)
+
+// Expand replaces every {{name}} placeholder in a template with a
+// value from the named generator, e.g:
+//
+//	f.Expand("{{person.name}} <{{internet.email}}>")
+func (f *Fakery) Expand(template string) (string, error) {
+	var err error
+
+	out := placeholderRe.ReplaceAllStringFunc(template, func(match string) string {
+		name := placeholderRe.FindStringSubmatch(match)[1]
+		value, ok := f.Generate(name)
+		if !ok {
+			if err == nil {
+				err = fmt.Errorf("error - unknown generator %q", name)
+			}
+			return match
+		}
+		return fmt.Sprint(value)
+	})
+
+	return out, err
+}
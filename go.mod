@@ -11,3 +11,7 @@ require (
 	github.com/mileusna/useragent v1.3.5
 	golang.org/x/text v0.26.0
 )
+
+require gopkg.in/yaml.v3 v3.0.1
+
+require google.golang.org/protobuf v1.36.12
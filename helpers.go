@@ -51,6 +51,8 @@ type LocaleData struct {
 
 // structure mapping locales to locale data
 type DataLoader struct {
+	// guards localeDataMap for concurrent generators
+	mu            sync.Mutex
 	localeDataMap map[string]*LocaleData
 	configIsMap   map[string]bool
 	// Common file path for a specific type of data
@@ -147,6 +149,9 @@ func (loader *DataLoader) Get(locale string) *LocaleData {
 	var val *LocaleData
 	var ok bool
 
+	loader.mu.Lock()
+	defer loader.mu.Unlock()
+
 	// already inited
 	if val, ok = loader.localeDataMap[locale]; ok {
 		return val
@@ -176,12 +181,10 @@ func (loader *DataLoader) EnsureLoaded(locale string) *LocaleData {
 		return nil
 	}
 
-	if !localeData.loaded {
-		err := localeData.Load()
-		if err != nil {
-			log.Printf("error - loading locale data for locale: %s - %v\n", locale, err)
-		}
-		return localeData
+	// Load is guarded by a sync.Once so this is cheap once loaded
+	err := localeData.Load()
+	if err != nil {
+		log.Printf("error - loading locale data for locale: %s - %v\n", locale, err)
 	}
 
 	return localeData
@@ -330,6 +333,16 @@ func SplitVowel(in string) []string {
 	return pieces
 }
 
+// Return whether in contains s ignoring case
+func containsFold(in []string, s string) bool {
+	for _, elem := range in {
+		if strings.EqualFold(elem, s) {
+			return true
+		}
+	}
+	return false
+}
+
 func startsWithVowel(in string) bool {
 	return strings.Contains("AEIOU", string(in[0]))
 }
@@ -2,8 +2,11 @@
 package fakery
 
 import (
+	"encoding/binary"
 	"fmt"
 	"strings"
+
+	"github.com/google/uuid"
 )
 
 var (
@@ -32,6 +35,11 @@ func (f *Fakery) FreeEmailDomain() string {
 	return f.RandomString(f.LoadGenericLocale(&netLoader).Get("free_email_domains"))
 }
 
+// Return whether domain is a free email provider such as gmail.com
+func (f *Fakery) IsFreeEmailDomain(domain string) bool {
+	return containsFold(f.LoadGenericLocale(&netLoader).Get("free_email_domains"), domain)
+}
+
 // return random email
 func (f *Fakery) Email() string {
 	var name string
@@ -57,10 +65,11 @@ func (f *Fakery) Email() string {
 
 // Return random email but with given first name and last name
 func (f *Fakery) EmailWithName(firstName, lastName string) string {
-	var prefix string
-	var domain string
+	return f.emailWithDomain(firstName, lastName, f.EmailDomain())
+}
 
-	domain = f.EmailDomain()
+func (f *Fakery) emailWithDomain(firstName, lastName, domain string) string {
+	var prefix string
 
 	if f.Choice() == 0 {
 		// first name first
@@ -144,3 +153,47 @@ func (f *Fakery) UserName() string {
 
 	return ""
 }
+
+// Return a random (version 4) UUID drawn from the instance's
+// random stream, so it is reproducible for a given seed
+func (f *Fakery) UUID() string {
+	var u uuid.UUID
+
+	binary.BigEndian.PutUint64(u[:8], f.rng.Uint64())
+	binary.BigEndian.PutUint64(u[8:], f.rng.Uint64())
+	// Set version 4 and the RFC 4122 variant
+	u[6] = (u[6] & 0x0f) | 0x40
+	u[8] = (u[8] & 0x3f) | 0x80
+
+	return u.String()
+}
+
+// Return a random public looking IPv4 address
+func (f *Fakery) IPv4() string {
+	return fmt.Sprintf("%d.%d.%d.%d", f.RandIntBetween(1, 224), f.IntRange(256),
+		f.IntRange(256), f.RandIntBetween(1, 255))
+}
+
+// Return a random IPv6 address
+func (f *Fakery) IPv6() string {
+	groups := make([]string, 8)
+	for i := range groups {
+		groups[i] = fmt.Sprintf("%x", f.IntRange(0x10000))
+	}
+	return strings.Join(groups, ":")
+}
+
+// Return a random domain name
+func (f *Fakery) DomainName() string {
+	return strings.ToLower(f.LastName()) + f.TLD()
+}
+
+// Return a random URL
+func (f *Fakery) URL() string {
+	var path string
+
+	if f.Choice() == 1 {
+		path = "/" + strings.ToLower(strings.ReplaceAll(f.Adjective(), " ", "-"))
+	}
+	return fmt.Sprintf("https://www.%s%s", f.DomainName(), path)
+}
@@ -0,0 +1,394 @@
+// Driver's licenses with the number formats of their jurisdiction
+package fakery
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Struct describing a driver's license
+type DriversLicense struct {
+	Number       string   `json:"number"`       // T1234567
+	Jurisdiction string   `json:"jurisdiction"` // US-TX, GB, IN or DE
+	Classes      []string `json:"classes"`      // C, M
+	FirstName    string   `json:"first_name"`
+	LastName     string   `json:"last_name"`
+	Sex          string   `json:"sex"` // M or F
+	Birthdate    string   `json:"birthdate"`
+	Address      string   `json:"address,omitempty"`
+	IssueDate    string   `json:"issue_date"`
+	ExpiryDate   string   `json:"expiry_date"`
+	Base
+}
+
+func (d DriversLicense) String() string {
+	return d.Base.String(d)
+}
+
+// A US state issuing licenses. In formats '#' is a digit, '@' a
+// letter and '$' the Soundex code of the surname.
+type usState struct {
+	name   string
+	format string
+}
+
+var usStates = map[string]usState{
+	"AL": {"Alabama", "#######"},
+	"AK": {"Alaska", "#######"},
+	"AZ": {"Arizona", "@########"},
+	"AR": {"Arkansas", "#########"},
+	"CA": {"California", "@#######"},
+	"CO": {"Colorado", "#########"},
+	"CT": {"Connecticut", "#########"},
+	"DE": {"Delaware", "#######"},
+	"DC": {"District of Columbia", "#######"},
+	"FL": {"Florida", "$#########"},
+	"GA": {"Georgia", "#########"},
+	"HI": {"Hawaii", "H########"},
+	"ID": {"Idaho", "@@######@"},
+	"IL": {"Illinois", "$########"},
+	"IN": {"Indiana", "##########"},
+	"IA": {"Iowa", "###@@####"},
+	"KS": {"Kansas", "K########"},
+	"KY": {"Kentucky", "@########"},
+	"LA": {"Louisiana", "#########"},
+	"ME": {"Maine", "#######"},
+	"MD": {"Maryland", "$#########"},
+	"MA": {"Massachusetts", "S########"},
+	"MI": {"Michigan", "$#########"},
+	"MN": {"Minnesota", "$#########"},
+	"MS": {"Mississippi", "#########"},
+	"MO": {"Missouri", "@#########"},
+	"MT": {"Montana", "#############"},
+	"NE": {"Nebraska", "@########"},
+	"NV": {"Nevada", "##########"},
+	"NH": {"New Hampshire", "##@@@#####"},
+	"NJ": {"New Jersey", "$###########"},
+	"NM": {"New Mexico", "#########"},
+	"NY": {"New York", "#########"},
+	"NC": {"North Carolina", "############"},
+	"ND": {"North Dakota", "@@@######"},
+	"OH": {"Ohio", "@@######"},
+	"OK": {"Oklahoma", "@#########"},
+	"OR": {"Oregon", "#######"},
+	"PA": {"Pennsylvania", "########"},
+	"RI": {"Rhode Island", "#######"},
+	"SC": {"South Carolina", "#########"},
+	"SD": {"South Dakota", "########"},
+	"TN": {"Tennessee", "#########"},
+	"TX": {"Texas", "########"},
+	"UT": {"Utah", "#########"},
+	"VT": {"Vermont", "########"},
+	"VA": {"Virginia", "@########"},
+	"WA": {"Washington", "WDL@@@###@@#"},
+	"WV": {"West Virginia", "@######"},
+	"WI": {"Wisconsin", "$##########"},
+	"WY": {"Wyoming", "#########"},
+}
+
+// Jurisdictions besides the US states
+const (
+	JurisdictionUK      = "GB"
+	JurisdictionIndia   = "IN"
+	JurisdictionGermany = "DE"
+)
+
+// Classes every holder has, and classes added at random
+type licenseClasses struct {
+	base  []string
+	extra []string
+}
+
+var licenseClassesByCountry = map[string]licenseClasses{
+	"US": {[]string{"C"}, []string{"M", "B", "A"}},
+	"GB": {[]string{"AM", "B", "f", "k", "p", "q"}, []string{"A", "BE", "C1"}},
+	"IN": {[]string{"LMV"}, []string{"MCWG", "TRANS"}},
+	"DE": {[]string{"AM", "B", "L"}, []string{"A", "BE", "C1", "CE"}},
+}
+
+// Years a license is valid for and the minimum age of holders
+var (
+	licenseValidity = map[string]int{"US": 8, "GB": 10, "IN": 20, "DE": 15}
+	licenseMinAge   = map[string]int{"US": 16, "GB": 17, "IN": 18, "DE": 18}
+)
+
+// Codes of Indian states and union territories
+var indianStates = []string{"AN", "AP", "AR", "AS", "BR", "CG", "CH", "DL", "GA", "GJ", "HP", "HR",
+	"JH", "JK", "KA", "KL", "LA", "MH", "ML", "MN", "MP", "MZ", "NL", "OD", "PB", "PY", "RJ", "SK",
+	"TN", "TR", "TS", "UK", "UP", "WB"}
+
+const alphanumerics = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+
+// Return a random driver's license of the locale's country. In
+// the US the license is issued by the state of a random address.
+func (f *Fakery) DriversLicense() *DriversLicense {
+	p := f.PersonWith()
+	if f.locale == "en_US" {
+		return f.DriversLicenseFor(p, f.AddressWith())
+	}
+	return f.DriversLicenseFor(p, nil)
+}
+
+// Return a driver's license for p, living at a. Licenses of the
+// UK, India and Germany are issued for their locales, otherwise
+// the US state of a issues it; a may be nil for a random state.
+// Use DriversLicenseIn to choose the jurisdiction.
+func (f *Fakery) DriversLicenseFor(p *Person, a *Address) *DriversLicense {
+	return f.driversLicense(f.licenseJurisdiction(a), p, a)
+}
+
+// Return a driver's license for p issued by a jurisdiction such as
+// GB, IN, DE or US-TX, whatever the locale. US alone stands for a
+// random state.
+func (f *Fakery) DriversLicenseIn(jurisdiction string, p *Person) (*DriversLicense, error) {
+	switch jurisdiction = strings.ToUpper(jurisdiction); jurisdiction {
+	case JurisdictionUK, JurisdictionIndia, JurisdictionGermany:
+		return f.driversLicense(jurisdiction, p, nil), nil
+	case "US":
+		return f.driversLicense(f.usJurisdiction(nil), p, nil), nil
+	}
+
+	state, ok := strings.CutPrefix(jurisdiction, "US-")
+	if _, known := usStates[state]; !ok || !known {
+		return nil, fmt.Errorf("error - unknown jurisdiction %q", jurisdiction)
+	}
+	return f.driversLicense(jurisdiction, p, nil), nil
+}
+
+func (f *Fakery) driversLicense(jurisdiction string, p *Person, a *Address) *DriversLicense {
+	d := DriversLicense{
+		Jurisdiction: jurisdiction,
+		FirstName:    p.FirstName,
+		LastName:     p.LastName,
+		Sex:          "M",
+		Birthdate:    p.Birthdate,
+	}
+	if Gender(p.Gender) == GenderFemale {
+		d.Sex = "F"
+	}
+	if a != nil {
+		d.Address = a.FullAddress
+	}
+
+	country, state, _ := strings.Cut(d.Jurisdiction, "-")
+	birth := p.DateOfBirth()
+
+	// Licenses are issued as of the date persons are aged, after
+	// the holder is old enough to drive
+	validity := licenseValidity[country]
+	issued := ageReferenceDate.AddDate(0, 0, -f.IntRange(validity*365/2))
+	if minIssue := birth.AddDate(licenseMinAge[country], 0, 0); issued.Before(minIssue) {
+		issued = minIssue
+	}
+	expiry := issued.AddDate(validity, 0, -1)
+	if country == "US" {
+		// US licenses expire on the birthday
+		expiry = time.Date(issued.Year()+validity, birth.Month(), birth.Day(), 0, 0, 0, 0, time.UTC)
+	}
+	d.IssueDate = issued.Format(DateLayout)
+	d.ExpiryDate = expiry.Format(DateLayout)
+
+	switch country {
+	case JurisdictionUK:
+		d.Number = f.dvlaNumber(p)
+	case JurisdictionIndia:
+		d.Number = fmt.Sprintf("%s%02d %d%07d", Pick(f, indianStates), f.RandIntBetween(1, 100), issued.Year(), f.IntRange(10000000))
+	case JurisdictionGermany:
+		d.Number = f.germanLicenseNumber()
+	default:
+		d.Number = f.usLicenseNumber(usStates[state].format, p.LastName)
+	}
+
+	classes := licenseClassesByCountry[country]
+	d.Classes = append([]string{}, classes.base...)
+	for _, class := range classes.extra {
+		if f.Chance(0.1) {
+			d.Classes = append(d.Classes, class)
+		}
+	}
+
+	return &d
+}
+
+// Return the jurisdiction issuing licenses in the locale's
+// country, or the US state of a, or a random state
+func (f *Fakery) licenseJurisdiction(a *Address) string {
+	switch country := f.localeCountryCode(); country {
+	case JurisdictionUK, JurisdictionIndia, JurisdictionGermany:
+		return country
+	}
+	return f.usJurisdiction(a)
+}
+
+// Return the US state of a, or a random state
+func (f *Fakery) usJurisdiction(a *Address) string {
+	codes := make([]string, 0, len(usStates))
+	for code, state := range usStates {
+		if a != nil && (strings.EqualFold(a.State, state.name) || strings.EqualFold(a.State, code)) {
+			return "US-" + code
+		}
+		codes = append(codes, code)
+	}
+	// Sort for the same choice from the same seed
+	sort.Strings(codes)
+	return "US-" + Pick(f, codes)
+}
+
+// Return a US license number of the format
+func (f *Fakery) usLicenseNumber(format, surname string) string {
+	format = strings.Replace(format, "$", soundex(surname), 1)
+	return f.Alphify(f.Numerify(format))
+}
+
+// Return the Soundex code of a name, a letter and three digits
+func soundex(name string) string {
+	const codes = "01230120022455012623010202"
+
+	var sb strings.Builder
+	var last byte
+	for _, r := range strings.ToUpper(name) {
+		if r < 'A' || r > 'Z' {
+			continue
+		}
+		code := codes[r-'A']
+		if sb.Len() == 0 {
+			sb.WriteRune(r)
+		} else if code != '0' && code != last {
+			sb.WriteByte(code)
+		}
+		// H and W do not separate letters of the same code
+		if r != 'H' && r != 'W' {
+			last = code
+		}
+		if sb.Len() == 4 {
+			break
+		}
+	}
+	if sb.Len() == 0 {
+		return "Z000"
+	}
+	return (sb.String() + "000")[:4]
+}
+
+// UK DVLA number: five letters of the surname padded with 9,
+// the decade, month (plus 50 for women), day and year of birth,
+// two initials padded with 9, a digit and two check letters
+func (f *Fakery) dvlaNumber(p *Person) string {
+	surname := strings.Map(func(r rune) rune {
+		if r >= 'A' && r <= 'Z' {
+			return r
+		}
+		return -1
+	}, strings.ToUpper(p.LastName))
+	if strings.HasPrefix(surname, "MAC") {
+		surname = "MC" + surname[3:]
+	}
+	surname = (surname + "99999")[:5]
+
+	initials := ""
+	for _, name := range strings.Fields(strings.ToUpper(p.FirstName)) {
+		if name[0] >= 'A' && name[0] <= 'Z' {
+			initials += name[:1]
+		}
+	}
+	initials = (initials + "99")[:2]
+
+	birth := p.DateOfBirth()
+	month := int(birth.Month())
+	if Gender(p.Gender) == GenderFemale {
+		month += 50
+	}
+
+	return fmt.Sprintf("%s%d%02d%02d%d%s9%s%s", surname, birth.Year()/10%10, month, birth.Day(),
+		birth.Year()%10, initials, f.RandomAZ(), f.RandomAZ())
+}
+
+// German license number: four characters of the issuing
+// authority, five of the serial, a mod 11 check character and
+// the number of the issue
+func (f *Fakery) germanLicenseNumber() string {
+	var sb strings.Builder
+	sb.WriteString(f.Alphify(f.Numerify("@###")))
+	for i := 0; i < 5; i++ {
+		sb.WriteByte(alphanumerics[f.IntRange(len(alphanumerics))])
+	}
+	sb.WriteByte(germanLicenseCheck(sb.String()))
+	sb.WriteString(strconv.Itoa(f.RandIntBetween(1, 10)))
+	return sb.String()
+}
+
+// Sum the values of the nine characters weighted 9 down to 1,
+// mod 11 with 10 written X
+func germanLicenseCheck(s string) byte {
+	sum := 0
+	for i := 0; i < 9; i++ {
+		sum += strings.IndexByte(alphanumerics, s[i]) * (9 - i)
+	}
+	if r := sum % 11; r < 10 {
+		return byte('0' + r)
+	}
+	return 'X'
+}
+
+var (
+	dvlaPattern          = regexp.MustCompile(`^[A-Z9]{5}\d([0156]\d)(\d{2})\d[A-Z9]{2}\d[A-Z]{2}$`)
+	indianLicensePattern = regexp.MustCompile(`^([A-Z]{2})\d{2}(\d{4})\d{7}$`)
+	germanLicensePattern = regexp.MustCompile(`^[A-Z0-9]{9}[0-9X][0-9]$`)
+	usLicensePatterns    = map[string]*regexp.Regexp{}
+)
+
+func init() {
+	for code, state := range usStates {
+		var sb strings.Builder
+		sb.WriteString("^")
+		for _, r := range state.format {
+			switch r {
+			case '#':
+				sb.WriteString(`\d`)
+			case '@':
+				sb.WriteString(`[A-Z]`)
+			case '$':
+				sb.WriteString(`[A-Z]\d{3}`)
+			default:
+				sb.WriteRune(r)
+			}
+		}
+		sb.WriteString("$")
+		usLicensePatterns[code] = regexp.MustCompile(sb.String())
+	}
+}
+
+// Validate a license number of a jurisdiction such as US-TX, GB,
+// IN or DE, with or without spaces and dashes
+func (f *Fakery) ValidateDriversLicense(jurisdiction, number string) bool {
+	number = strings.NewReplacer(" ", "", "-", "").Replace(strings.ToUpper(number))
+
+	switch jurisdiction = strings.ToUpper(jurisdiction); jurisdiction {
+	case JurisdictionUK:
+		m := dvlaPattern.FindStringSubmatch(number)
+		if m == nil {
+			return false
+		}
+		month, _ := strconv.Atoi(m[1])
+		day, _ := strconv.Atoi(m[2])
+		return (month >= 1 && month <= 12 || month >= 51 && month <= 62) && day >= 1 && day <= 31
+
+	case JurisdictionIndia:
+		m := indianLicensePattern.FindStringSubmatch(number)
+		if m == nil || !containsFold(indianStates, m[1]) {
+			return false
+		}
+		year, _ := strconv.Atoi(m[2])
+		return year >= 1950 && year < 2100
+
+	case JurisdictionGermany:
+		return germanLicensePattern.MatchString(number) && germanLicenseCheck(number) == number[9]
+	}
+
+	pattern, ok := usLicensePatterns[strings.TrimPrefix(jurisdiction, "US-")]
+	return ok && pattern.MatchString(number)
+}
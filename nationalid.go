@@ -0,0 +1,561 @@
+// National identity numbers with valid check digits
+package fakery
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Kind of national identity number
+type NationalIDType string
+
+const (
+	IDSSN           NationalIDType = "ssn"            // US Social Security number
+	IDITIN          NationalIDType = "itin"           // US Individual Taxpayer Identification number
+	IDNINO          NationalIDType = "nino"           // UK National Insurance number
+	IDAadhaar       NationalIDType = "aadhaar"        // Indian Aadhaar number
+	IDPAN           NationalIDType = "pan"            // Indian Permanent Account number
+	IDCPF           NationalIDType = "cpf"            // Brazilian individual taxpayer number
+	IDCNPJ          NationalIDType = "cnpj"           // Brazilian company number
+	IDDNI           NationalIDType = "dni"            // Spanish national identity document
+	IDNIE           NationalIDType = "nie"            // Spanish foreigner identity number
+	IDCodiceFiscale NationalIDType = "codice_fiscale" // Italian tax code
+	IDINSEE         NationalIDType = "insee"          // French social security number
+	IDBSN           NationalIDType = "bsn"            // Dutch citizen service number
+)
+
+// Generator and validator of one kind of number
+type nationalID struct {
+	// Persons are used for the numbers which encode the name,
+	// sex or birthdate and may be nil for the others
+	generate func(f *Fakery, p *Person) string
+	validate func(id string) bool
+}
+
+// Filled in init, as generating numbers may generate a Person
+var nationalIDs map[NationalIDType]nationalID
+
+func init() {
+	nationalIDs = map[NationalIDType]nationalID{
+		IDSSN:           {generateSSN, validateSSN},
+		IDITIN:          {generateITIN, validateITIN},
+		IDNINO:          {generateNINO, validateNINO},
+		IDAadhaar:       {generateAadhaar, validateAadhaar},
+		IDPAN:           {generatePAN, validatePAN},
+		IDCPF:           {generateCPF, validateCPF},
+		IDCNPJ:          {generateCNPJ, validateCNPJ},
+		IDDNI:           {generateDNI, validateDNI},
+		IDNIE:           {generateNIE, validateNIE},
+		IDCodiceFiscale: {generateCodiceFiscale, validateCodiceFiscale},
+		IDINSEE:         {generateINSEE, validateINSEE},
+		IDBSN:           {generateBSN, validateBSN},
+	}
+
+	// Register national_id.ssn and so on
+	for _, idType := range NationalIDTypes {
+		idType := idType
+		Register("national_id."+string(idType), func(f *Fakery) interface{} { return f.NationalID(idType) })
+	}
+}
+
+// The number issued to persons of a country, by country code
+var countryNationalIDs = map[string]NationalIDType{
+	"US": IDSSN,
+	"GB": IDNINO,
+	"IN": IDAadhaar,
+	"BR": IDCPF,
+	"ES": IDDNI,
+	"IT": IDCodiceFiscale,
+	"FR": IDINSEE,
+	"NL": IDBSN,
+}
+
+// NationalIDTypes lists the supported kinds of number
+var NationalIDTypes = []NationalIDType{IDSSN, IDITIN, IDNINO, IDAadhaar, IDPAN, IDCPF, IDCNPJ,
+	IDDNI, IDNIE, IDCodiceFiscale, IDINSEE, IDBSN}
+
+// Return the kind of number issued in the country of the locale
+func (f *Fakery) LocaleNationalIDType() (NationalIDType, bool) {
+	t, ok := countryNationalIDs[f.localeCountryCode()]
+	return t, ok
+}
+
+// Return a random national identity number of the given kind,
+// or "" for an unknown kind
+func (f *Fakery) NationalID(idType NationalIDType) string {
+	return f.NationalIDFor(nil, idType)
+}
+
+// Return a national identity number of the given kind for p.
+// Numbers such as the Codice Fiscale are derived from the name,
+// sex and birthdate; for a nil p a random person is used.
+func (f *Fakery) NationalIDFor(p *Person, idType NationalIDType) string {
+	id, ok := nationalIDs[idType]
+	if !ok {
+		return ""
+	}
+	return id.generate(f, p)
+}
+
+// Validate a national identity number of the given kind, with or
+// without its usual separators
+func (f *Fakery) ValidateNationalID(idType NationalIDType, id string) bool {
+	nid, ok := nationalIDs[idType]
+	if !ok {
+		return false
+	}
+	return nid.validate(strings.ToUpper(strings.TrimSpace(id)))
+}
+
+// Return p, or a random person if it is nil
+func (f *Fakery) personOrRandom(p *Person) *Person {
+	if p != nil {
+		return p
+	}
+	return f.PersonWith()
+}
+
+// Strip the separators used when printing numbers
+func stripIDSeparators(id string) string {
+	return strings.NewReplacer(" ", "", "-", "", ".", "", "/", "").Replace(id)
+}
+
+// Parse a string of digits
+func digitsOf(s string) ([]int, bool) {
+	digits := make([]int, len(s))
+	for i, r := range s {
+		if r < '0' || r > '9' {
+			return nil, false
+		}
+		digits[i] = int(r - '0')
+	}
+	return digits, true
+}
+
+// Return n random digits, the first one non zero
+func (f *Fakery) randomDigits(n int) []int {
+	digits := make([]int, n)
+	for i := range digits {
+		digits[i] = f.IntRange(10)
+	}
+	digits[0] = f.RandDigitNonZero()
+	return digits
+}
+
+func joinDigits(digits []int) string {
+	var sb strings.Builder
+	for _, d := range digits {
+		sb.WriteByte(byte('0' + d))
+	}
+	return sb.String()
+}
+
+// US SSN: area 001-899 except 666, group 01-99, serial 0001-9999
+var ssnPattern = regexp.MustCompile(`^(\d{3})-?(\d{2})-?(\d{4})$`)
+
+func generateSSN(f *Fakery, _ *Person) string {
+	area := f.RandIntBetween(1, 900)
+	for area == 666 {
+		area = f.RandIntBetween(1, 900)
+	}
+	return fmt.Sprintf("%03d-%02d-%04d", area, f.RandIntBetween(1, 100), f.RandIntBetween(1, 10000))
+}
+
+func validateSSN(id string) bool {
+	m := ssnPattern.FindStringSubmatch(id)
+	if m == nil {
+		return false
+	}
+	area, _ := strconv.Atoi(m[1])
+	return area > 0 && area < 900 && area != 666 && m[2] != "00" && m[3] != "0000"
+}
+
+// US ITIN: area 900-999 with groups 50-65, 70-88, 90-92 and 94-99
+var itinGroups = [][2]int{{50, 65}, {70, 88}, {90, 92}, {94, 99}}
+
+func generateITIN(f *Fakery, _ *Person) string {
+	groups := Pick(f, itinGroups)
+	return fmt.Sprintf("9%02d-%02d-%04d", f.IntRange(100), f.RandIntBetween(groups[0], groups[1]+1), f.RandIntBetween(1, 10000))
+}
+
+func validateITIN(id string) bool {
+	m := ssnPattern.FindStringSubmatch(id)
+	if m == nil || m[1][0] != '9' {
+		return false
+	}
+	group, _ := strconv.Atoi(m[2])
+	for _, g := range itinGroups {
+		if group >= g[0] && group <= g[1] {
+			return true
+		}
+	}
+	return false
+}
+
+// UK NINO: two prefix letters, six digits and a suffix A-D
+var ninoPattern = regexp.MustCompile(`^[A-CEGHJ-PR-TW-Z][A-CEGHJ-NPR-TW-Z]\d{6}[A-D]$`)
+
+var ninoBadPrefixes = []string{"BG", "GB", "KN", "NK", "NT", "TN", "ZZ"}
+
+func generateNINO(f *Fakery, _ *Person) string {
+	for {
+		id := fmt.Sprintf("%s%s%06d%s", f.RandomAZ(), f.RandomAZ(), f.IntRange(1000000), f.RandomAZSpecific("D"))
+		if validateNINO(id) {
+			return id
+		}
+	}
+}
+
+func validateNINO(id string) bool {
+	id = stripIDSeparators(id)
+	if !ninoPattern.MatchString(id) {
+		return false
+	}
+	for _, bad := range ninoBadPrefixes {
+		if id[:2] == bad {
+			return false
+		}
+	}
+	return true
+}
+
+// Verhoeff check digit tables
+var (
+	verhoeffD = [10][10]int{
+		{0, 1, 2, 3, 4, 5, 6, 7, 8, 9},
+		{1, 2, 3, 4, 0, 6, 7, 8, 9, 5},
+		{2, 3, 4, 0, 1, 7, 8, 9, 5, 6},
+		{3, 4, 0, 1, 2, 8, 9, 5, 6, 7},
+		{4, 0, 1, 2, 3, 9, 5, 6, 7, 8},
+		{5, 9, 8, 7, 6, 0, 4, 3, 2, 1},
+		{6, 5, 9, 8, 7, 1, 0, 4, 3, 2},
+		{7, 6, 5, 9, 8, 2, 1, 0, 4, 3},
+		{8, 7, 6, 5, 9, 3, 2, 1, 0, 4},
+		{9, 8, 7, 6, 5, 4, 3, 2, 1, 0},
+	}
+	verhoeffP = [8][10]int{
+		{0, 1, 2, 3, 4, 5, 6, 7, 8, 9},
+		{1, 5, 7, 6, 2, 8, 3, 0, 9, 4},
+		{5, 8, 0, 3, 7, 9, 6, 1, 4, 2},
+		{8, 9, 1, 6, 0, 4, 3, 5, 2, 7},
+		{9, 4, 5, 3, 1, 2, 6, 8, 7, 0},
+		{4, 2, 8, 6, 5, 7, 3, 9, 0, 1},
+		{2, 7, 9, 3, 8, 0, 6, 4, 1, 5},
+		{7, 0, 4, 6, 9, 1, 3, 2, 5, 8},
+	}
+	verhoeffInv = [10]int{0, 4, 3, 2, 1, 5, 6, 7, 8, 9}
+)
+
+// Return the Verhoeff check digit for digits
+func verhoeffDigit(digits []int) int {
+	c := 0
+	for i := range digits {
+		c = verhoeffD[c][verhoeffP[(i+1)%8][digits[len(digits)-1-i]]]
+	}
+	return verhoeffInv[c]
+}
+
+// Aadhaar: 12 digits not starting with 0 or 1, the last a
+// Verhoeff check digit
+func generateAadhaar(f *Fakery, _ *Person) string {
+	digits := f.randomDigits(11)
+	digits[0] = f.RandIntBetween(2, 10)
+	id := joinDigits(append(digits, verhoeffDigit(digits)))
+
+	return id[:4] + " " + id[4:8] + " " + id[8:]
+}
+
+func validateAadhaar(id string) bool {
+	digits, ok := digitsOf(stripIDSeparators(id))
+	if !ok || len(digits) != 12 || digits[0] < 2 {
+		return false
+	}
+	return verhoeffDigit(digits[:11]) == digits[11]
+}
+
+// PAN: five letters, four digits and a letter. The fourth letter
+// is the holder type, P for a person, and the fifth the initial
+// of the surname.
+var panPattern = regexp.MustCompile(`^[A-Z]{3}[ABCFGHJLPT][A-Z]\d{4}[A-Z]$`)
+
+func generatePAN(f *Fakery, p *Person) string {
+	p = f.personOrRandom(p)
+
+	initial := strings.ToUpper(p.LastName)
+	if initial == "" || initial[0] < 'A' || initial[0] > 'Z' {
+		initial = f.RandomAZ()
+	}
+	return fmt.Sprintf("%s%s%sP%c%04d%s", f.RandomAZ(), f.RandomAZ(), f.RandomAZ(), initial[0], f.RandIntBetween(1, 10000), f.RandomAZ())
+}
+
+func validatePAN(id string) bool {
+	return panPattern.MatchString(id)
+}
+
+// Return the Brazilian mod 11 check digit for digits and weights
+func mod11Digit(digits, weights []int) int {
+	sum := 0
+	for i, d := range digits {
+		sum += d * weights[i]
+	}
+	if r := sum % 11; r >= 2 {
+		return 11 - r
+	}
+	return 0
+}
+
+// Weights from n+1 down to 2
+func descendingWeights(n int) []int {
+	weights := make([]int, n)
+	for i := range weights {
+		weights[i] = n + 1 - i
+	}
+	return weights
+}
+
+func allSame(digits []int) bool {
+	for _, d := range digits {
+		if d != digits[0] {
+			return false
+		}
+	}
+	return true
+}
+
+// CPF: nine digits and two mod 11 check digits
+func generateCPF(f *Fakery, _ *Person) string {
+	digits := f.randomDigits(9)
+	digits = append(digits, mod11Digit(digits, descendingWeights(9)))
+	digits = append(digits, mod11Digit(digits, descendingWeights(10)))
+
+	id := joinDigits(digits)
+	return id[:3] + "." + id[3:6] + "." + id[6:9] + "-" + id[9:]
+}
+
+func validateCPF(id string) bool {
+	digits, ok := digitsOf(stripIDSeparators(id))
+	if !ok || len(digits) != 11 || allSame(digits) {
+		return false
+	}
+	return mod11Digit(digits[:9], descendingWeights(9)) == digits[9] &&
+		mod11Digit(digits[:10], descendingWeights(10)) == digits[10]
+}
+
+// CNPJ weights cycle from 9 down to 2
+var (
+	cnpjWeights1 = []int{5, 4, 3, 2, 9, 8, 7, 6, 5, 4, 3, 2}
+	cnpjWeights2 = []int{6, 5, 4, 3, 2, 9, 8, 7, 6, 5, 4, 3, 2}
+)
+
+// CNPJ: eight digits of the company, four of the branch and two
+// mod 11 check digits
+func generateCNPJ(f *Fakery, _ *Person) string {
+	digits := append(f.randomDigits(8), 0, 0, 0, 1)
+	digits = append(digits, mod11Digit(digits, cnpjWeights1))
+	digits = append(digits, mod11Digit(digits, cnpjWeights2))
+
+	id := joinDigits(digits)
+	return id[:2] + "." + id[2:5] + "." + id[5:8] + "/" + id[8:12] + "-" + id[12:]
+}
+
+func validateCNPJ(id string) bool {
+	digits, ok := digitsOf(stripIDSeparators(id))
+	if !ok || len(digits) != 14 || allSame(digits) {
+		return false
+	}
+	return mod11Digit(digits[:12], cnpjWeights1) == digits[12] &&
+		mod11Digit(digits[:13], cnpjWeights2) == digits[13]
+}
+
+// Control letters of DNI and NIE by number mod 23
+const dniLetters = "TRWAGMYFPDXBNJZSQVHLCKE"
+
+// DNI: eight digits and a control letter
+func generateDNI(f *Fakery, _ *Person) string {
+	n := f.IntRange(100000000)
+	return fmt.Sprintf("%08d%c", n, dniLetters[n%23])
+}
+
+func validateDNI(id string) bool {
+	id = stripIDSeparators(id)
+	if len(id) != 9 {
+		return false
+	}
+	n, err := strconv.Atoi(id[:8])
+	return err == nil && id[:8] == fmt.Sprintf("%08d", n) && id[8] == dniLetters[n%23]
+}
+
+// NIE: X, Y or Z, seven digits and a control letter computed as
+// for a DNI with X, Y and Z read as 0, 1 and 2
+func generateNIE(f *Fakery, _ *Person) string {
+	prefix := f.IntRange(3)
+	n := f.IntRange(10000000)
+	return fmt.Sprintf("%c%07d%c", "XYZ"[prefix], n, dniLetters[(prefix*10000000+n)%23])
+}
+
+func validateNIE(id string) bool {
+	id = stripIDSeparators(id)
+	if len(id) != 9 {
+		return false
+	}
+	prefix := strings.IndexByte("XYZ", id[0])
+	if prefix < 0 {
+		return false
+	}
+	return validateDNI(strconv.Itoa(prefix) + id[1:])
+}
+
+// Codice Fiscale month letters
+const cfMonths = "ABCDEHLMPRST"
+
+// Codice Fiscale values of characters in odd positions, 0-9 then A-Z
+var cfOdd = []int{1, 0, 5, 7, 9, 13, 15, 17, 19, 21,
+	1, 0, 5, 7, 9, 13, 15, 17, 19, 21, 2, 4, 18, 20, 11, 3, 6, 8, 12, 14, 16, 10, 22, 25, 24, 23}
+
+// Cadastral codes of a few large Italian municipalities
+var cfPlaces = []string{"H501", "F205", "F839", "L219", "G273", "A944", "D612", "L736", "A662", "C351"}
+
+var cfPattern = regexp.MustCompile(`^[A-Z]{6}\d{2}[ABCDEHLMPRST]\d{2}[A-Z]\d{3}[A-Z]$`)
+
+// Split the ASCII letters of s into consonants and vowels
+func consonantsAndVowels(s string) (string, string) {
+	var cons, vowels strings.Builder
+	for _, r := range strings.ToUpper(s) {
+		switch {
+		case strings.ContainsRune("AEIOU", r):
+			vowels.WriteRune(r)
+		case r >= 'A' && r <= 'Z':
+			cons.WriteRune(r)
+		}
+	}
+	return cons.String(), vowels.String()
+}
+
+// Three letters for the surname: its consonants, then vowels,
+// padded with X
+func cfSurname(surname string) string {
+	cons, vowels := consonantsAndVowels(surname)
+	return (cons + vowels + "XXX")[:3]
+}
+
+// Three letters for the first name, which skip the second
+// consonant if there are four or more
+func cfName(name string) string {
+	cons, vowels := consonantsAndVowels(name)
+	if len(cons) >= 4 {
+		return cons[:1] + cons[2:4]
+	}
+	return (cons + vowels + "XXX")[:3]
+}
+
+// Value of a character of the Codice Fiscale at 0 based index i
+func cfValue(c byte, i int) int {
+	idx := int(c - '0')
+	if c >= 'A' {
+		idx = int(c-'A') + 10
+	}
+	// Odd positions counting from 1
+	if i%2 == 0 {
+		return cfOdd[idx]
+	}
+	if c >= 'A' {
+		return int(c - 'A')
+	}
+	return int(c - '0')
+}
+
+func cfCheck(code string) byte {
+	sum := 0
+	for i := 0; i < len(code); i++ {
+		sum += cfValue(code[i], i)
+	}
+	return byte('A' + sum%26)
+}
+
+// Codice Fiscale: surname, name, birth year, month and day (plus
+// 40 for women), place of birth and a check letter
+func generateCodiceFiscale(f *Fakery, p *Person) string {
+	p = f.personOrRandom(p)
+	birth := p.DateOfBirth()
+
+	day := birth.Day()
+	if p.Gender == string(GenderFemale) {
+		day += 40
+	}
+
+	code := fmt.Sprintf("%s%s%02d%c%02d%s", cfSurname(p.LastName), cfName(p.FirstName),
+		birth.Year()%100, cfMonths[birth.Month()-1], day, Pick(f, cfPlaces))
+	return code + string(cfCheck(code))
+}
+
+func validateCodiceFiscale(id string) bool {
+	if !cfPattern.MatchString(id) {
+		return false
+	}
+	return cfCheck(id[:15]) == id[15]
+}
+
+// INSEE: sex, birth year and month, department, commune, order
+// of birth and a key of 97 minus the number mod 97
+func generateINSEE(f *Fakery, p *Person) string {
+	p = f.personOrRandom(p)
+	birth := p.DateOfBirth()
+
+	sex := 1
+	if p.Gender == string(GenderFemale) {
+		sex = 2
+	}
+	// Corsica (2A and 2B) is left out to keep the number numeric
+	dept := f.RandIntBetween(1, 96)
+	for dept == 20 {
+		dept = f.RandIntBetween(1, 96)
+	}
+
+	number := fmt.Sprintf("%d%02d%02d%02d%03d%03d", sex, birth.Year()%100, int(birth.Month()),
+		dept, f.RandIntBetween(1, 991), f.RandIntBetween(1, 1000))
+	n, _ := strconv.ParseInt(number, 10, 64)
+	key := 97 - n%97
+
+	return fmt.Sprintf("%s %s %s %s %s %s %02d", number[:1], number[1:3], number[3:5], number[5:7], number[7:10], number[10:], key)
+}
+
+func validateINSEE(id string) bool {
+	id = stripIDSeparators(id)
+	if _, ok := digitsOf(id); !ok || len(id) != 15 || (id[0] != '1' && id[0] != '2') {
+		return false
+	}
+	n, _ := strconv.ParseInt(id[:13], 10, 64)
+	key, _ := strconv.ParseInt(id[13:], 10, 64)
+	return key == 97-n%97
+}
+
+// BSN: nine digits passing the 11-proof, the weighted sum with
+// weights 9 to 2 and -1 being divisible by 11
+func bsnSum(digits []int) int {
+	sum := -digits[8]
+	for i := 0; i < 8; i++ {
+		sum += digits[i] * (9 - i)
+	}
+	return sum
+}
+
+func generateBSN(f *Fakery, _ *Person) string {
+	for {
+		digits := append(f.randomDigits(8), 0)
+		last := bsnSum(digits) % 11
+		if last < 10 {
+			digits[8] = last
+			return joinDigits(digits)
+		}
+	}
+}
+
+func validateBSN(id string) bool {
+	digits, ok := digitsOf(stripIDSeparators(id))
+	if !ok || len(digits) != 9 || allSame(digits) {
+		return false
+	}
+	return bsnSum(digits)%11 == 0
+}
@@ -0,0 +1,42 @@
+// Optional and nullable values for exercising missing data
+package fakery
+
+import (
+	"database/sql"
+)
+
+// Return true with probability p
+func (f *Fakery) Chance(p float64) bool {
+	return f.rng.Float64() < p
+}
+
+// Return a pointer to a value from gen, or nil with probability
+// nullProb. E.g: f.Maybe(0.2, f.Email)
+func (f *Fakery) Maybe(nullProb float64, gen func() string) *string {
+	return Nullable(f, nullProb, gen)
+}
+
+// Return a sql.NullString which is invalid with probability nullProb
+func (f *Fakery) MaybeNullString(nullProb float64, gen func() string) sql.NullString {
+	if f.Chance(nullProb) {
+		return sql.NullString{}
+	}
+	return sql.NullString{String: gen(), Valid: true}
+}
+
+// Return a sql.NullInt64 which is invalid with probability nullProb
+func (f *Fakery) MaybeNullInt64(nullProb float64, gen func() int) sql.NullInt64 {
+	if f.Chance(nullProb) {
+		return sql.NullInt64{}
+	}
+	return sql.NullInt64{Int64: int64(gen()), Valid: true}
+}
+
+// Generic form of Maybe for values of any type
+func Nullable[T any](f *Fakery, nullProb float64, gen func() T) *T {
+	if f.Chance(nullProb) {
+		return nil
+	}
+	v := gen()
+	return &v
+}
@@ -0,0 +1,304 @@
+// Passports, ID cards and visas with machine readable zones
+package fakery
+
+import (
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// Kind of travel document
+type DocumentType string
+
+const (
+	DocPassport DocumentType = "passport" // TD3, two lines of 44 characters
+	DocIDCard   DocumentType = "id_card"  // TD1, three lines of 30 characters
+	DocVisa     DocumentType = "visa"     // MRV-A, two lines of 44 characters
+)
+
+// Struct describing a travel document following ICAO 9303
+type TravelDocument struct {
+	Type           DocumentType `json:"type"`
+	Number         string       `json:"number"`          // 563810274
+	IssuingCountry string       `json:"issuing_country"` // USA
+	Nationality    string       `json:"nationality"`     // USA
+	Surname        string       `json:"surname"`         // O'Neil
+	GivenNames     string       `json:"given_names"`     // Mary Ann
+	Sex            string       `json:"sex"`             // M, F or < if unspecified
+	Birthdate      string       `json:"birthdate"`       // 1984-03-21
+	IssueDate      string       `json:"issue_date"`
+	ExpiryDate     string       `json:"expiry_date"`
+	MRZ            []string     `json:"mrz"` // Machine readable zone, one string per line
+	Base
+}
+
+func (d TravelDocument) String() string {
+	return d.Base.String(d)
+}
+
+// ISO 3166 three letter codes by two letter code. Germany is
+// written D in machine readable zones.
+var alpha3Codes = map[string]string{
+	"AR": "ARG", "AT": "AUT", "AU": "AUS", "BE": "BEL", "BR": "BRA", "CA": "CAN", "CH": "CHE",
+	"CL": "CHL", "CN": "CHN", "CO": "COL", "CZ": "CZE", "DE": "D", "DK": "DNK", "EG": "EGY",
+	"ES": "ESP", "FI": "FIN", "FR": "FRA", "GB": "GBR", "GR": "GRC", "HU": "HUN", "ID": "IDN",
+	"IE": "IRL", "IL": "ISR", "IN": "IND", "IT": "ITA", "JP": "JPN", "KE": "KEN", "KR": "KOR",
+	"MA": "MAR", "MX": "MEX", "NG": "NGA", "NL": "NLD", "NO": "NOR", "NZ": "NZL", "PE": "PER",
+	"PH": "PHL", "PK": "PAK", "PL": "POL", "PT": "PRT", "RO": "ROU", "SE": "SWE", "SG": "SGP",
+	"TH": "THA", "TR": "TUR", "UA": "UKR", "US": "USA", "VN": "VNM", "ZA": "ZAF",
+}
+
+// Formats of document numbers by country, '#' is a digit and '@'
+// a letter. Other countries use docNumberFormat.
+var docNumberFormats = map[string]string{
+	"USA": "#########",
+	"GBR": "#########",
+	"IND": "@#######",
+	"D":   "C@@#####@",
+	"FRA": "##@@#####",
+	"ITA": "@@#######",
+	"ESP": "@@@######",
+	"NLD": "@@@@#####",
+}
+
+const docNumberFormat = "@@#######"
+
+// Years a document is valid for
+var docValidity = map[DocumentType]int{
+	DocPassport: 10,
+	DocIDCard:   10,
+	DocVisa:     2,
+}
+
+// Return the two letter code of the locale's country, e.g. US
+// for en_US
+func (f *Fakery) localeCountryCode() string {
+	_, country, _ := strings.Cut(f.locale, "_")
+	return strings.ToUpper(country)
+}
+
+// Return the three letter code of the locale's country, or of a
+// random country if it has none
+func (f *Fakery) localeAlpha3() string {
+	if code, ok := alpha3Codes[f.localeCountryCode()]; ok {
+		return code
+	}
+	return f.randomAlpha3("")
+}
+
+// Return a random three letter country code other than except
+func (f *Fakery) randomAlpha3(except string) string {
+	codes := make([]string, 0, len(alpha3Codes))
+	for _, code := range alpha3Codes {
+		if code != except {
+			codes = append(codes, code)
+		}
+	}
+	// Sort for the same choice from the same seed
+	sort.Strings(codes)
+	return Pick(f, codes)
+}
+
+// Return a random passport of the locale's country
+func (f *Fakery) Passport() *TravelDocument {
+	return f.PassportFor(f.PersonWith())
+}
+
+// Return a random ID card of the locale's country
+func (f *Fakery) IDCard() *TravelDocument {
+	return f.IDCardFor(f.PersonWith())
+}
+
+// Return a random visa issued by a foreign country to a citizen
+// of the locale's country
+func (f *Fakery) Visa() *TravelDocument {
+	return f.VisaFor(f.PersonWith())
+}
+
+// Return a passport of the locale's country for p, whose name,
+// sex and birthdate are those in the MRZ
+func (f *Fakery) PassportFor(p *Person) *TravelDocument {
+	country := f.localeAlpha3()
+	return f.travelDocument(p, DocPassport, country, country)
+}
+
+// Return an ID card of the locale's country for p
+func (f *Fakery) IDCardFor(p *Person) *TravelDocument {
+	country := f.localeAlpha3()
+	return f.travelDocument(p, DocIDCard, country, country)
+}
+
+// Return a visa for p, a citizen of the locale's country,
+// issued by another country
+func (f *Fakery) VisaFor(p *Person) *TravelDocument {
+	nationality := f.localeAlpha3()
+	return f.travelDocument(p, DocVisa, f.randomAlpha3(nationality), nationality)
+}
+
+func (f *Fakery) travelDocument(p *Person, docType DocumentType, issuer, nationality string) *TravelDocument {
+	format, ok := docNumberFormats[issuer]
+	if !ok {
+		format = docNumberFormat
+	}
+
+	d := TravelDocument{
+		Type:           docType,
+		Number:         f.Alphify(f.Numerify(format)),
+		IssuingCountry: issuer,
+		Nationality:    nationality,
+		Surname:        p.LastName,
+		GivenNames:     p.FirstName,
+		Sex:            "<",
+		Birthdate:      p.Birthdate,
+	}
+	switch Gender(p.Gender) {
+	case GenderMale:
+		d.Sex = "M"
+	case GenderFemale:
+		d.Sex = "F"
+	}
+
+	// Documents are issued as of the date persons are aged, in
+	// the first half of their validity so most are still valid
+	validity := docValidity[docType]
+	issued := ageReferenceDate.AddDate(0, 0, -f.IntRange(validity*365/2))
+	if birth := p.DateOfBirth(); issued.Before(birth) {
+		issued = birth
+	}
+	d.IssueDate = issued.Format(DateLayout)
+	d.ExpiryDate = issued.AddDate(validity, 0, -1).Format(DateLayout)
+
+	d.MRZ = d.mrz()
+	return &d
+}
+
+// Return the MRZ lines of the document
+func (d *TravelDocument) mrz() []string {
+	number := mrzField(d.Number, 9)
+	numberCheck := mrzCheckDigit(number)
+	birth := mrzDate(d.Birthdate)
+	birthCheck := mrzCheckDigit(birth)
+	expiry := mrzDate(d.ExpiryDate)
+	expiryCheck := mrzCheckDigit(expiry)
+	issuer := mrzField(d.IssuingCountry, 3)
+	nationality := mrzField(d.Nationality, 3)
+
+	switch d.Type {
+	case DocIDCard:
+		line1 := "I<" + issuer + number + numberCheck + mrzField("", 15)
+		line2 := birth + birthCheck + d.Sex + expiry + expiryCheck + nationality + mrzField("", 11)
+		composite := mrzCheckDigit(line1[5:] + line2[:7] + line2[8:15] + line2[18:])
+		return []string{line1, line2 + composite, mrzName(d.Surname, d.GivenNames, 30)}
+
+	case DocVisa:
+		line1 := "V<" + issuer + mrzName(d.Surname, d.GivenNames, 39)
+		line2 := number + numberCheck + nationality + birth + birthCheck + d.Sex + expiry + expiryCheck + mrzField("", 16)
+		return []string{line1, line2}
+	}
+
+	line1 := "P<" + issuer + mrzName(d.Surname, d.GivenNames, 39)
+	personal := mrzField("", 14)
+	line2 := number + numberCheck + nationality + birth + birthCheck + d.Sex + expiry + expiryCheck +
+		personal + mrzCheckDigit(personal)
+	composite := mrzCheckDigit(line2[:10] + line2[13:20] + line2[21:43])
+	return []string{line1, line2 + composite}
+}
+
+// Upper case s, strip diacritics and apostrophes, and replace
+// other characters but A-Z and 0-9 by fillers
+func mrzText(s string) string {
+	var sb strings.Builder
+	for _, r := range norm.NFD.String(strings.ToUpper(s)) {
+		switch {
+		case unicode.Is(unicode.Mn, r), r == '\'':
+		case (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9'):
+			sb.WriteRune(r)
+		default:
+			sb.WriteByte('<')
+		}
+	}
+	return sb.String()
+}
+
+// Return s as an MRZ field of the given width, truncated or
+// padded with fillers
+func mrzField(s string, width int) string {
+	s = mrzText(s)
+	if len(s) > width {
+		return s[:width]
+	}
+	return s + strings.Repeat("<", width-len(s))
+}
+
+// Return the name field, the surname and given names separated
+// by two fillers
+func mrzName(surname, givenNames string, width int) string {
+	name := mrzText(surname)
+	if givenNames != "" {
+		name += "<<" + mrzText(givenNames)
+	}
+	return mrzField(name, width)
+}
+
+// Return a date as YYMMDD
+func mrzDate(date string) string {
+	t, err := time.Parse(DateLayout, date)
+	if err != nil {
+		return "<<<<<<"
+	}
+	return t.Format("060102")
+}
+
+// Return the check digit of an MRZ field, the sum of its values
+// weighted 7, 3, 1 mod 10
+func mrzCheckDigit(field string) string {
+	weights := [3]int{7, 3, 1}
+	sum := 0
+	for i := 0; i < len(field); i++ {
+		c := field[i]
+		value := 0
+		switch {
+		case c >= '0' && c <= '9':
+			value = int(c - '0')
+		case c >= 'A' && c <= 'Z':
+			value = int(c-'A') + 10
+		}
+		sum += value * weights[i%3]
+	}
+	return string(rune('0' + sum%10))
+}
+
+// Validate the check digits of a TD1, TD3 or MRV-A machine
+// readable zone
+func (f *Fakery) ValidateMRZ(lines []string) bool {
+	checks := func(line string, fields ...[2]int) bool {
+		for _, field := range fields {
+			if mrzCheckDigit(line[field[0]:field[1]]) != line[field[1]:field[1]+1] {
+				return false
+			}
+		}
+		return true
+	}
+
+	switch {
+	case len(lines) == 3 && len(lines[0]) == 30 && len(lines[1]) == 30 && len(lines[2]) == 30:
+		l1, l2 := lines[0], lines[1]
+		return checks(l1, [2]int{5, 14}) && checks(l2, [2]int{0, 6}, [2]int{8, 14}) &&
+			mrzCheckDigit(l1[5:]+l2[:7]+l2[8:15]+l2[18:29]) == l2[29:]
+
+	case len(lines) == 2 && len(lines[0]) == 44 && len(lines[1]) == 44:
+		l2 := lines[1]
+		if !checks(l2, [2]int{0, 9}, [2]int{13, 19}, [2]int{21, 27}) {
+			return false
+		}
+		if lines[0][0] == 'V' {
+			return true
+		}
+		return checks(l2, [2]int{28, 42}) &&
+			mrzCheckDigit(l2[:10]+l2[13:20]+l2[21:43]) == l2[43:]
+	}
+	return false
+}
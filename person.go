@@ -3,6 +3,7 @@ package fakery
 
 import (
 	"strings"
+	"time"
 )
 
 type Gender string
@@ -31,11 +32,14 @@ type Person struct {
 	FirstName string `json:"first_name"`
 	LastName  string `json:"last_name,omitempty"`
 	Gender    string `json:"gender"`
+	Birthdate string `json:"birthdate"` // 1984-03-21
 	Prefix    string `json:"prefix,omitempty"`
 	Suffix    string `json:"suffix,omitempty"`
 	Username  string `json:"user_name,omitempty"`
 	Email     string `json:"email"`
 	Job       string `json:"job"`
+	// Number of the national identity of the locale's country
+	NationalID string `json:"national_id,omitempty"`
 	Base
 }
 
@@ -54,6 +58,40 @@ func (p Person) String() string {
 	return p.Base.String(p)
 }
 
+// Return the date of birth, the zero time if it is not set
+func (p Person) DateOfBirth() time.Time {
+	t, _ := time.Parse(DateLayout, p.Birthdate)
+	return t
+}
+
+// Return the age in years as of today
+func (p Person) Age() int {
+	return ageAt(p.DateOfBirth(), time.Now())
+}
+
+// Layout of dates such as birthdates
+const DateLayout = "2006-01-02"
+
+// Unless an age is asked for, persons are aged as of a fixed
+// date rather than today so that seeded persons do not change
+// over time
+var ageReferenceDate = time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// Default range of ages
+const (
+	minPersonAge = 18
+	maxPersonAge = 90
+)
+
+// Years from birth until the given time
+func ageAt(birth, t time.Time) int {
+	age := t.Year() - birth.Year()
+	if t.Month() < birth.Month() || (t.Month() == birth.Month() && t.Day() < birth.Day()) {
+		age--
+	}
+	return age
+}
+
 var nameFormats = WeightedArray{
 	Items: []WeightedItem{
 		// firstName lastName format - most common
@@ -104,6 +142,43 @@ func (f *Fakery) FirstName() string {
 	return firstName
 }
 
+// Return random male first name
+func (f *Fakery) FirstNameMale() string {
+	return f.RandomString(f.LoadLocale(&personLoader).Get("first_name_male"))
+}
+
+// Return random female first name
+func (f *Fakery) FirstNameFemale() string {
+	return f.RandomString(f.LoadLocale(&personLoader).Get("first_name_female"))
+}
+
+// Return whether name is a first name in the locale data
+func (f *Fakery) IsFirstName(name string) bool {
+	data := f.LoadLocale(&personLoader)
+	return containsFold(data.Get("first_name_male"), name) || containsFold(data.Get("first_name_female"), name)
+}
+
+// Return whether name is a last name in the locale data
+func (f *Fakery) IsLastName(name string) bool {
+	return containsFold(f.LoadLocale(&personLoader).Get("last_name"), name)
+}
+
+// Return the gender of a first name as listed in the locale data.
+// ok is false for names which are not listed or listed as both.
+func (f *Fakery) NameGender(firstName string) (gender Gender, ok bool) {
+	data := f.LoadLocale(&personLoader)
+
+	male := containsFold(data.Get("first_name_male"), firstName)
+	female := containsFold(data.Get("first_name_female"), firstName)
+	if male == female {
+		return "", false
+	}
+	if male {
+		return GenderMale, true
+	}
+	return GenderFemale, true
+}
+
 // Return random last name
 func (f *Fakery) LastName() string {
 
@@ -115,93 +190,170 @@ func (f *Fakery) LastName() string {
 	return lastName
 }
 
+// Return a random birthdate for an age in [minAge, maxAge] today
+func (f *Fakery) Birthdate(minAge, maxAge int) string {
+	return f.birthdate(minAge, maxAge, time.Now().UTC())
+}
+
+// Return a random birthdate for an age in [minAge, maxAge] on day t,
+// the bounds being swapped if minAge > maxAge
+func (f *Fakery) birthdate(minAge, maxAge int, t time.Time) string {
+	if minAge > maxAge {
+		minAge, maxAge = maxAge, minAge
+	}
+	age := minAge + f.IntRange(maxAge-minAge+1)
+
+	// Born in the year up to the day which makes one age years old
+	latest := t.AddDate(-age, 0, 0)
+	days := int(latest.Sub(latest.AddDate(-1, 0, 0)).Hours() / 24)
+
+	return latest.AddDate(0, 0, -f.IntRange(days)).Format(DateLayout)
+}
+
 // returns a fake Person object
 func (f *Fakery) Person() *Person {
-	var person *Person
+	return f.PersonWith()
+}
 
-	gender := f.Gender()
+// Settings of PersonWith
+type personSpec struct {
+	gender    Gender
+	firstName string
+	lastName  string
+	minAge    int
+	maxAge    int
+	asOf      time.Time
+	// e.g: {{prefix}} {{firstName}} {{lastName}}
+	nameFormat  string
+	emailDomain string
+}
 
-	switch gender {
-	case GenderMale:
-		person = f.PersonMale()
-	case GenderFemale:
-		person = f.PersonFemale()
-	}
+// Option pinning a field of PersonWith
+type PersonOption func(*personSpec)
 
-	// Fill in rest
-	person.Email = f.EmailWithName(person.FirstName, person.LastName)
-	person.Job = f.Job().Title
-	return person
+// Pin the gender
+func WithGender(gender Gender) PersonOption {
+	return func(s *personSpec) { s.gender = gender }
 }
 
-// Returns a fake Person object with Male gender
-func (f *Fakery) PersonMale() *Person {
+// Pin the first name. Unless pinned as well, the gender
+// follows the name where the locale data tells it.
+func WithFirstName(name string) PersonOption {
+	return func(s *personSpec) { s.firstName = name }
+}
 
-	var person Person
-	var nameFormat string
-	var namePieces []string
+// Pin the last name
+func WithLastName(name string) PersonOption {
+	return func(s *personSpec) { s.lastName = name }
+}
 
-	nameFormat, err := f.RandomWeightedItem(&nameFormats)
-	if err != nil {
-		return nil
+// Limit the age today to [min, max] years, a fixed age if min and
+// max are equal. The bounds are swapped if min > max.
+func WithAge(min, max int) PersonOption {
+	return func(s *personSpec) {
+		s.minAge, s.maxAge = min, max
+		s.asOf = time.Now().UTC()
 	}
+}
 
-	data := f.LoadLocale(&personLoader)
+// Format the full name, with the placeholders {{prefix}},
+// {{firstName}}, {{lastName}} and {{suffix}}. The prefix and
+// suffix are only set when they are in the format.
+func WithNameFormat(format string) PersonOption {
+	return func(s *personSpec) { s.nameFormat = format }
+}
 
-	if strings.Contains(nameFormat, "{{prefix}}") {
-		person.Prefix = f.RandomString(data.Get("prefix_male"))
-		namePieces = append(namePieces, person.Prefix)
-	}
+// Use the given domain for the email address
+func WithEmailDomain(domain string) PersonOption {
+	return func(s *personSpec) { s.emailDomain = domain }
+}
 
-	person.FirstName = f.RandomString(data.Get("first_name_male"))
-	person.LastName = f.RandomString(data.Get("last_name"))
-	person.Name = strings.Join([]string{person.FirstName, person.LastName}, " ")
+// Return a fake Person with the given fields pinned, the others
+// generated to match, e.g:
+//
+//	f.PersonWith(fakery.WithGender(fakery.GenderFemale), fakery.WithLastName("Smith"))
+func (f *Fakery) PersonWith(opts ...PersonOption) *Person {
+	spec := personSpec{minAge: minPersonAge, maxAge: maxPersonAge, asOf: ageReferenceDate}
+	for _, opt := range opts {
+		opt(&spec)
+	}
 
-	namePieces = append(namePieces, person.FirstName)
-	namePieces = append(namePieces, person.LastName)
+	person := f.personName(spec)
 
-	if strings.Contains(nameFormat, "{{suffix}}") {
-		person.Suffix = f.RandomString(data.Get("suffix_male"))
-		namePieces = append(namePieces, person.Suffix)
+	// Fill in rest, the fields added later drawn last so that seeded
+	// persons keep their names, emails and jobs
+	if spec.emailDomain != "" {
+		person.Email = f.emailWithDomain(person.FirstName, person.LastName, spec.emailDomain)
+	} else {
+		person.Email = f.EmailWithName(person.FirstName, person.LastName)
 	}
-
-	person.FullName = strings.Join(namePieces, " ")
-	person.Gender = string(GenderMale)
-	return &person
+	person.Job = f.Job().Title
+	person.Birthdate = f.birthdate(spec.minAge, spec.maxAge, spec.asOf)
+	if idType, ok := f.LocaleNationalIDType(); ok {
+		person.NationalID = f.NationalIDFor(person, idType)
+	}
+	return person
 }
 
-// Returns a fake Person object with Female gender
-func (f *Fakery) PersonFemale() *Person {
+// Return a Person with only the gender and name fields set
+func (f *Fakery) personName(spec personSpec) *Person {
+	if spec.gender == "" && spec.firstName != "" {
+		spec.gender, _ = f.NameGender(spec.firstName)
+	}
+	if spec.gender == "" {
+		spec.gender = f.Gender()
+	}
 
 	var person Person
-	var nameFormat string
-	var namePieces []string
+	person.Gender = string(spec.gender)
 
-	nameFormat, err := f.RandomWeightedItem(&nameFormats)
-	if err != nil {
-		return nil
+	nameFormat := spec.nameFormat
+	if nameFormat == "" {
+		nameFormat, _ = f.RandomWeightedItem(&nameFormats)
 	}
 
+	// Locale data keys are suffixed by gender, e.g: prefix_female
 	data := f.LoadLocale(&personLoader)
+	gender := "male"
+	if spec.gender == GenderFemale {
+		gender = "female"
+	}
 
 	if strings.Contains(nameFormat, "{{prefix}}") {
-		person.Prefix = f.RandomString(data.Get("prefix_female"))
-		namePieces = append(namePieces, person.Prefix)
+		person.Prefix = f.RandomString(data.Get("prefix_" + gender))
+	}
+	person.FirstName = spec.firstName
+	if person.FirstName == "" {
+		person.FirstName = f.RandomString(data.Get("first_name_" + gender))
+	}
+	person.LastName = spec.lastName
+	if person.LastName == "" {
+		person.LastName = f.RandomString(data.Get("last_name"))
 	}
-
-	person.FirstName = f.RandomString(data.Get("first_name_female"))
-	person.LastName = f.RandomString(data.Get("last_name"))
-	person.Name = strings.Join([]string{person.FirstName, person.LastName}, " ")
-
-	namePieces = append(namePieces, person.FirstName)
-	namePieces = append(namePieces, person.LastName)
-
 	if strings.Contains(nameFormat, "{{suffix}}") {
-		person.Suffix = f.RandomString(data.Get("suffix_female"))
-		namePieces = append(namePieces, person.Suffix)
+		person.Suffix = f.RandomString(data.Get("suffix_" + gender))
 	}
 
-	person.FullName = strings.Join(namePieces, " ")
-	person.Gender = string(GenderFemale)
+	person.Name = strings.Join([]string{person.FirstName, person.LastName}, " ")
+	person.FullName = strings.NewReplacer(
+		"{{prefix}}", person.Prefix,
+		"{{firstName}}", person.FirstName,
+		"{{lastName}}", person.LastName,
+		"{{suffix}}", person.Suffix,
+	).Replace(nameFormat)
+	// Close the gaps of empty pieces
+	person.FullName = strings.Join(strings.Fields(person.FullName), " ")
 	return &person
 }
+
+// Returns a fake Person object with Male gender, only its gender
+// and name fields set
+func (f *Fakery) PersonMale() *Person {
+	return f.personName(personSpec{gender: GenderMale})
+}
+
+// Returns a fake Person object with Female gender, only its gender
+// and name fields set
+func (f *Fakery) PersonFemale() *Person {
+	return f.personName(personSpec{gender: GenderFemale})
+}
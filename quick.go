@@ -0,0 +1,66 @@
+// Adapters for property based testing with testing/quick. The
+// record types implement quick.Generator, so they can be used as
+// arguments of quick.Check functions, e.g:
+//
+//	quick.Check(func(p fakery.Person) bool { ... }, nil)
+//
+// Use the value types - a *Person argument is not supported.
+package fakery
+
+import (
+	"math/rand"
+	"reflect"
+)
+
+// Return a Fakery seeded from the random source of testing/quick
+func quickFakery(r *rand.Rand) *Fakery {
+	return NewFromSeed(r.Int63())
+}
+
+func (Person) Generate(r *rand.Rand, size int) reflect.Value {
+	return reflect.ValueOf(*quickFakery(r).Person())
+}
+
+func (Address) Generate(r *rand.Rand, size int) reflect.Value {
+	return reflect.ValueOf(*quickFakery(r).Address())
+}
+
+func (CreditCard) Generate(r *rand.Rand, size int) reflect.Value {
+	return reflect.ValueOf(*quickFakery(r).CreditCard())
+}
+
+func (Car) Generate(r *rand.Rand, size int) reflect.Value {
+	return reflect.ValueOf(*quickFakery(r).Car())
+}
+
+func (Book) Generate(r *rand.Rand, size int) reflect.Value {
+	return reflect.ValueOf(*quickFakery(r).Book())
+}
+
+func (Color) Generate(r *rand.Rand, size int) reflect.Value {
+	return reflect.ValueOf(*quickFakery(r).Color())
+}
+
+func (Beer) Generate(r *rand.Rand, size int) reflect.Value {
+	return reflect.ValueOf(*quickFakery(r).Beer())
+}
+
+func (Wine) Generate(r *rand.Rand, size int) reflect.Value {
+	return reflect.ValueOf(*quickFakery(r).Wine())
+}
+
+func (Currency) Generate(r *rand.Rand, size int) reflect.Value {
+	return reflect.ValueOf(*quickFakery(r).Currency())
+}
+
+func (Emoji) Generate(r *rand.Rand, size int) reflect.Value {
+	return reflect.ValueOf(*quickFakery(r).Emoji())
+}
+
+func (Blood) Generate(r *rand.Rand, size int) reflect.Value {
+	return reflect.ValueOf(*quickFakery(r).Blood())
+}
+
+func (Job) Generate(r *rand.Rand, size int) reflect.Value {
+	return reflect.ValueOf(*quickFakery(r).Job())
+}
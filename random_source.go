@@ -0,0 +1,57 @@
+// Pluggable random sources
+package fakery
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	randv2 "math/rand/v2"
+)
+
+// Source is a source of uniformly distributed uint64 values.
+// It is the same as math/rand/v2.Source so any of those
+// sources can be passed to NewWithSource directly.
+type Source interface {
+	Uint64() uint64
+}
+
+// Fixed PCG increment used with the seed. Changing this
+// changes every value produced for a given seed.
+const pcgStream = 0x9e3779b97f4a7c15
+
+// Return a PCG source for the given seed. This is the
+// default source and it supports snapshots.
+func NewPCGSource(seed int64) Source {
+	return randv2.NewPCG(uint64(seed), pcgStream)
+}
+
+// Return a ChaCha8 source for the given seed. The seed is
+// expanded to the 32 byte ChaCha8 key with SHA-256.
+func NewChaCha8Source(seed int64) Source {
+	var buf [8]byte
+
+	binary.BigEndian.PutUint64(buf[:], uint64(seed))
+	return randv2.NewChaCha8(sha256.Sum256(buf[:]))
+}
+
+// Return a source reading from crypto/rand. Use it for tokens
+// and passwords where values must not be predictable. Such
+// instances cannot be reproduced or snapshotted.
+func NewCryptoSource() Source {
+	return cryptoSource{}
+}
+
+type cryptoSource struct{}
+
+func (cryptoSource) Uint64() uint64 {
+	var buf [8]byte
+
+	// crypto/rand.Read never returns an error
+	rand.Read(buf[:])
+	return binary.BigEndian.Uint64(buf[:])
+}
+
+// A random seed from system entropy for unseeded constructors
+func entropySeed() int64 {
+	return int64(cryptoSource{}.Uint64())
+}
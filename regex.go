@@ -0,0 +1,98 @@
+// Generate strings matching a regular expression
+package fakery
+
+import (
+	"fmt"
+	"regexp/syntax"
+	"strings"
+)
+
+// Upper bound for the unbounded repeats *, + and {n,}
+const maxRegexRepeat = 5
+
+// Printable ASCII used for '.' and negated classes
+const printableChars = " !\"#$%&'()*+,-./0123456789:;<=>?@ABCDEFGHIJKLMNOPQRSTUVWXYZ[\\]^_`abcdefghijklmnopqrstuvwxyz{|}~"
+
+// Return a random string matching the given regular expression.
+// Anchors and word boundaries are ignored and unbounded repeats
+// are capped to a few repetitions.
+func (f *Fakery) Regexify(pattern string) (string, error) {
+	re, err := syntax.Parse(pattern, syntax.Perl)
+	if err != nil {
+		return "", fmt.Errorf("error - invalid pattern %q: %w", pattern, err)
+	}
+
+	var sb strings.Builder
+	f.regexify(re.Simplify(), &sb)
+	return sb.String(), nil
+}
+
+func (f *Fakery) regexify(re *syntax.Regexp, sb *strings.Builder) {
+	switch re.Op {
+	case syntax.OpLiteral:
+		for _, r := range re.Rune {
+			if re.Flags&syntax.FoldCase != 0 && f.Choice() == 1 {
+				r = []rune(strings.ToUpper(string(r)))[0]
+			}
+			sb.WriteRune(r)
+		}
+	case syntax.OpCharClass:
+		sb.WriteRune(f.runeFromClass(re.Rune))
+	case syntax.OpAnyChar, syntax.OpAnyCharNotNL:
+		sb.WriteByte(printableChars[f.IntRange(len(printableChars))])
+	case syntax.OpCapture:
+		f.regexify(re.Sub[0], sb)
+	case syntax.OpConcat:
+		for _, sub := range re.Sub {
+			f.regexify(sub, sb)
+		}
+	case syntax.OpAlternate:
+		f.regexify(re.Sub[f.IntRange(len(re.Sub))], sb)
+	case syntax.OpStar, syntax.OpPlus, syntax.OpQuest, syntax.OpRepeat:
+		min, max := repeatBounds(re)
+		count := f.RandIntBetween(min, max+1)
+		for i := 0; i < count; i++ {
+			f.regexify(re.Sub[0], sb)
+		}
+	}
+	// Anchors, boundaries and empty matches produce nothing
+}
+
+func repeatBounds(re *syntax.Regexp) (int, int) {
+	switch re.Op {
+	case syntax.OpStar:
+		return 0, maxRegexRepeat
+	case syntax.OpPlus:
+		return 1, maxRegexRepeat
+	case syntax.OpQuest:
+		return 0, 1
+	}
+
+	max := re.Max
+	if max == -1 {
+		max = re.Min + maxRegexRepeat
+	}
+	return re.Min, max
+}
+
+// Pick a rune from a class given as [lo, hi] pairs. Printable
+// ASCII is preferred so that negated classes stay readable.
+func (f *Fakery) runeFromClass(ranges []rune) rune {
+	var printable []rune
+
+	for _, c := range printableChars {
+		for i := 0; i+1 < len(ranges); i += 2 {
+			if c >= ranges[i] && c <= ranges[i+1] {
+				printable = append(printable, c)
+				break
+			}
+		}
+	}
+	if len(printable) > 0 {
+		return printable[f.IntRange(len(printable))]
+	}
+
+	// Fall back to any rune of a random range
+	i := 2 * f.IntRange(len(ranges)/2)
+	return ranges[i] + rune(f.IntRange(int(ranges[i+1]-ranges[i])+1))
+}
@@ -0,0 +1,89 @@
+// Generic sampling helpers. None of these modify the input slice,
+// so they are safe to use on the package level data slices.
+package fakery
+
+import (
+	"fmt"
+	"slices"
+)
+
+// Return a random item of xs
+func Pick[T any](f *Fakery, xs []T) T {
+	return xs[f.IntRange(len(xs))]
+}
+
+// Return a random item of xs which is not one of excl. The
+// zero value is returned if every item is excluded.
+func PickExcept[T comparable](f *Fakery, xs []T, excl ...T) T {
+	var candidates []T
+
+	for _, x := range xs {
+		if !slices.Contains(excl, x) {
+			candidates = append(candidates, x)
+		}
+	}
+
+	if len(candidates) == 0 {
+		var zero T
+		return zero
+	}
+	return Pick(f, candidates)
+}
+
+// Return k distinct items of xs (without replacement) in random
+// order. If k exceeds len(xs) all items are returned, if it is
+// negative none.
+func Sample[T any](f *Fakery, xs []T, k int) []T {
+	k = MaxInt(0, MinInt(k, len(xs)))
+	out := slices.Clone(xs)
+
+	// Partial Fisher-Yates - only the first k slots are needed
+	for i := 0; i < k; i++ {
+		j := i + f.IntRange(len(out)-i)
+		out[i], out[j] = out[j], out[i]
+	}
+
+	return out[:k]
+}
+
+// Return a shuffled copy of xs
+func Shuffle[T any](f *Fakery, xs []T) []T {
+	return Sample(f, xs, len(xs))
+}
+
+// Return an item of xs chosen with probability proportional to
+// its weight. Weights need not add to 1.0. An error is returned
+// if the lengths differ or the total weight is not positive.
+func WeightedPick[T any](f *Fakery, xs []T, weights []float64) (T, error) {
+	var zero T
+
+	if len(xs) != len(weights) {
+		return zero, fmt.Errorf("error - %d items but %d weights", len(xs), len(weights))
+	}
+
+	var total float64
+	for _, w := range weights {
+		total += w
+	}
+	if total <= 0 {
+		return zero, fmt.Errorf("error - total weight %v is not positive", total)
+	}
+
+	randVal := f.rng.Float64() * total
+
+	var cumulativeWeight float64
+	for i, w := range weights {
+		cumulativeWeight += w
+		if randVal < cumulativeWeight {
+			return xs[i], nil
+		}
+	}
+
+	// Fallback in case of rounding errors
+	return xs[len(xs)-1], nil
+}
+
+// Return a random permutation of the integers [0, n)
+func (f *Fakery) Permutation(n int) []int {
+	return f.rng.Perm(n)
+}
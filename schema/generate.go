@@ -0,0 +1,482 @@
+// Generation of values per schema keyword
+package schema
+
+import (
+	"fakery"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Generate a value for schema s. name is the property name the
+// value is for, used to pick realistic generators.
+func (g *Generator) generate(node interface{}, name string, depth int) (interface{}, error) {
+	if depth > g.MaxDepth+maxRequiredDepth {
+		return nil, fmt.Errorf("error - schema recursion exceeds max depth %d", g.MaxDepth)
+	}
+
+	// true is the schema accepting anything
+	if b, ok := node.(bool); ok {
+		if !b {
+			return nil, fmt.Errorf("error - schema false accepts no value")
+		}
+		return g.f.Adjective(), nil
+	}
+
+	s, ok := node.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("error - invalid schema %v", node)
+	}
+
+	if ref, ok := s["$ref"].(string); ok {
+		target, err := g.resolve(ref)
+		if err != nil {
+			return nil, err
+		}
+		return g.generate(target, name, depth+1)
+	}
+
+	if v, ok := s["const"]; ok {
+		return v, nil
+	}
+	if enum, ok := s["enum"].([]interface{}); ok && len(enum) > 0 {
+		return fakery.Pick(g.f, enum), nil
+	}
+
+	for _, key := range []string{"oneOf", "anyOf"} {
+		if options, ok := s[key].([]interface{}); ok && len(options) > 0 {
+			return g.generate(fakery.Pick(g.f, options), name, depth+1)
+		}
+	}
+	if all, ok := s["allOf"].([]interface{}); ok && len(all) > 0 {
+		merged, err := g.mergeAllOf(s, all)
+		if err != nil {
+			return nil, err
+		}
+		return g.generate(merged, name, depth)
+	}
+
+	typ := schemaType(s)
+	if depth >= g.MaxDepth && (typ == "object" || typ == "array") {
+		// Required properties and items of recursive schemas end
+		// with the null or scalar branch of the type if it has one
+		if alt, ok := scalarType(s); ok {
+			typ = alt
+		}
+	}
+
+	switch typ {
+	case "object":
+		return g.object(s, depth)
+	case "array":
+		return g.array(s, name, depth)
+	case "integer":
+		return g.integer(s)
+	case "number":
+		return g.number(s)
+	case "boolean":
+		return g.f.Choice() == 1, nil
+	case "null":
+		return nil, nil
+	}
+	return g.str(s, name)
+}
+
+// Return the type of a schema, inferring it from other keywords
+// when missing. Of a list of types the first non null one is used.
+func schemaType(s map[string]interface{}) string {
+	switch t := s["type"].(type) {
+	case string:
+		return t
+	case []interface{}:
+		for _, item := range t {
+			if name, ok := item.(string); ok && name != "null" {
+				return name
+			}
+		}
+		return "null"
+	}
+
+	switch {
+	case s["properties"] != nil:
+		return "object"
+	case s["items"] != nil:
+		return "array"
+	case s["minimum"] != nil || s["maximum"] != nil:
+		return "number"
+	}
+	return "string"
+}
+
+// Return the first type of a list of types which is neither an
+// object nor an array
+func scalarType(s map[string]interface{}) (string, bool) {
+	types, _ := s["type"].([]interface{})
+	for _, item := range types {
+		if name, ok := item.(string); ok && name != "object" && name != "array" {
+			return name, true
+		}
+	}
+	return "", false
+}
+
+// Merge the subschemas of allOf into one object schema
+func (g *Generator) mergeAllOf(s map[string]interface{}, all []interface{}) (map[string]interface{}, error) {
+	merged := map[string]interface{}{}
+	properties := map[string]interface{}{}
+	var required []interface{}
+
+	parts := append([]interface{}{s}, all...)
+	for _, part := range parts {
+		for {
+			m, ok := part.(map[string]interface{})
+			if !ok {
+				return nil, fmt.Errorf("error - invalid allOf schema %v", part)
+			}
+			ref, isRef := m["$ref"].(string)
+			if !isRef {
+				break
+			}
+			var err error
+			if part, err = g.resolve(ref); err != nil {
+				return nil, err
+			}
+		}
+
+		m := part.(map[string]interface{})
+		for key, value := range m {
+			switch key {
+			case "allOf":
+			case "properties":
+				props, ok := value.(map[string]interface{})
+				if !ok {
+					return nil, fmt.Errorf("error - invalid allOf properties %v", value)
+				}
+				for pk, pv := range props {
+					properties[pk] = pv
+				}
+			case "required":
+				names, ok := value.([]interface{})
+				if !ok {
+					return nil, fmt.Errorf("error - invalid allOf required %v", value)
+				}
+				required = append(required, names...)
+			default:
+				merged[key] = value
+			}
+		}
+	}
+
+	merged["properties"] = properties
+	merged["required"] = required
+	merged["type"] = "object"
+	return merged, nil
+}
+
+func (g *Generator) object(s map[string]interface{}, depth int) (interface{}, error) {
+	out := map[string]interface{}{}
+	properties, _ := s["properties"].(map[string]interface{})
+
+	required := map[string]bool{}
+	if list, ok := s["required"].([]interface{}); ok {
+		for _, item := range list {
+			if name, ok := item.(string); ok {
+				required[name] = true
+			}
+		}
+	}
+
+	// Iterate in a fixed order so a seed gives the same document
+	names := make([]string, 0, len(properties))
+	for name := range properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		// Optional properties are included half the time and
+		// dropped entirely once the depth limit is reached
+		if !required[name] && (depth >= g.MaxDepth || g.f.Choice() == 0) {
+			continue
+		}
+
+		v, err := g.generate(properties[name], name, depth+1)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		out[name] = v
+	}
+
+	for name := range required {
+		if _, ok := out[name]; !ok {
+			return nil, fmt.Errorf("error - required property %q has no schema", name)
+		}
+	}
+
+	return out, nil
+}
+
+func (g *Generator) array(s map[string]interface{}, name string, depth int) (interface{}, error) {
+	min := int(number(s, "minItems", 0))
+	max := int(number(s, "maxItems", float64(min+3)))
+	if depth >= g.MaxDepth {
+		max = min
+	}
+
+	items, ok := s["items"]
+	if !ok {
+		items = map[string]interface{}{"type": "string"}
+	}
+
+	unique, _ := s["uniqueItems"].(bool)
+	count := g.f.RandIntBetween(min, max+1)
+	out := make([]interface{}, 0, count)
+	seen := map[string]bool{}
+
+	for attempts := 0; len(out) < count && attempts < 10*count; attempts++ {
+		v, err := g.generate(items, singular(name), depth+1)
+		if err != nil {
+			return nil, err
+		}
+
+		key := fmt.Sprint(v)
+		if unique && seen[key] {
+			continue
+		}
+		seen[key] = true
+		out = append(out, v)
+	}
+
+	return out, nil
+}
+
+// Items of "emails" are emails
+func singular(name string) string {
+	return strings.TrimSuffix(name, "s")
+}
+
+// Return a numeric keyword, or def when it is missing
+func number(s map[string]interface{}, key string, def float64) float64 {
+	switch v := s[key].(type) {
+	case int:
+		return float64(v)
+	case float64:
+		return v
+	}
+	return def
+}
+
+// Numeric bounds, honouring the exclusive forms of draft 2020-12
+// and the boolean ones of OpenAPI 3.0. A missing bound is the
+// default, moved to keep the range as wide as the default one
+// when the other bound lies beyond it.
+func bounds(s map[string]interface{}, defMin, defMax float64) (float64, float64, bool, bool) {
+	min, max := number(s, "minimum", math.NaN()), number(s, "maximum", math.NaN())
+	exclMin, exclMax := false, false
+
+	if v, ok := s["exclusiveMinimum"].(bool); ok {
+		exclMin = v
+	} else if v := number(s, "exclusiveMinimum", math.NaN()); !math.IsNaN(v) {
+		min, exclMin = v, true
+	}
+	if v, ok := s["exclusiveMaximum"].(bool); ok {
+		exclMax = v
+	} else if v := number(s, "exclusiveMaximum", math.NaN()); !math.IsNaN(v) {
+		max, exclMax = v, true
+	}
+
+	switch {
+	case math.IsNaN(min) && math.IsNaN(max):
+		min, max = defMin, defMax
+	case math.IsNaN(min):
+		min = math.Min(defMin, max-(defMax-defMin))
+	case math.IsNaN(max):
+		max = math.Max(defMax, min+(defMax-defMin))
+	}
+	return min, max, exclMin, exclMax
+}
+
+func (g *Generator) integer(s map[string]interface{}) (interface{}, error) {
+	min, max, exclMin, exclMax := bounds(s, 0, 1000)
+
+	lo, hi := int(math.Ceil(min)), int(math.Floor(max))
+	if exclMin && float64(lo) == min {
+		lo++
+	}
+	if exclMax && float64(hi) == max {
+		hi--
+	}
+
+	if step := int(number(s, "multipleOf", 1)); step > 1 {
+		first := int(math.Ceil(float64(lo)/float64(step))) * step
+		last := int(math.Floor(float64(hi)/float64(step))) * step
+		if first > last {
+			return nil, fmt.Errorf("error - no multiple of %d in [%d, %d]", step, lo, hi)
+		}
+		return first + step*g.f.IntRange((last-first)/step+1), nil
+	}
+
+	if hi < lo {
+		return nil, fmt.Errorf("error - no integer in [%v, %v]", min, max)
+	}
+	return g.f.RandIntBetween(lo, hi+1), nil
+}
+
+func (g *Generator) number(s map[string]interface{}) (interface{}, error) {
+	min, max, exclMin, exclMax := bounds(s, 0, 1000)
+
+	if step := number(s, "multipleOf", 0); step > 0 {
+		// Draw k for k*step within the bounds
+		first, last := math.Ceil(min/step), math.Floor(max/step)
+		if exclMin && first*step <= min {
+			first++
+		}
+		if exclMax && last*step >= max {
+			last--
+		}
+		if first > last {
+			return nil, fmt.Errorf("error - no multiple of %v in [%v, %v]", step, min, max)
+		}
+		return (first + float64(g.f.IntRange(int(last-first)+1))) * step, nil
+	}
+
+	if max < min || (max == min && (exclMin || exclMax)) {
+		return nil, fmt.Errorf("error - no number in [%v, %v]", min, max)
+	}
+
+	// Two decimals, kept strictly inside exclusive bounds
+	v := math.Round((min+g.f.Float64()*(max-min))*100) / 100
+	if v <= min && exclMin {
+		v = min + 0.01
+	}
+	if v >= max && exclMax {
+		v = max - 0.01
+	}
+	return math.Max(min, math.Min(max, v)), nil
+}
+
+// Draws of a formatted string to find one of the allowed length
+const maxFormatDraws = 20
+
+// Generators for string formats
+var formats = map[string]func(f *fakery.Fakery) string{
+	"email":         (*fakery.Fakery).Email,
+	"idn-email":     (*fakery.Fakery).Email,
+	"uuid":          (*fakery.Fakery).UUID,
+	"ipv4":          (*fakery.Fakery).IPv4,
+	"ipv6":          (*fakery.Fakery).IPv6,
+	"hostname":      (*fakery.Fakery).DomainName,
+	"idn-hostname":  (*fakery.Fakery).DomainName,
+	"uri":           (*fakery.Fakery).URL,
+	"url":           (*fakery.Fakery).URL,
+	"iri":           (*fakery.Fakery).URL,
+	"uri-reference": (*fakery.Fakery).URL,
+	"date-time":     func(f *fakery.Fakery) string { return randomTime(f).Format(time.RFC3339) },
+	"date":          func(f *fakery.Fakery) string { return randomTime(f).Format(time.DateOnly) },
+	"time":          func(f *fakery.Fakery) string { return randomTime(f).Format("15:04:05Z") },
+}
+
+// Times are drawn before a fixed date rather than now, so that
+// seeded output does not change over time
+var referenceTime = time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// A time within the five years before referenceTime
+func randomTime(f *fakery.Fakery) time.Time {
+	return referenceTime.Add(-time.Duration(f.IntRange(5*365*24*3600)) * time.Second)
+}
+
+// Generators for well known property names, keyed by the
+// lowercased name with separators removed
+var wellKnownNames = map[string]string{
+	"name":          "person.name",
+	"fullname":      "person.name",
+	"firstname":     "person.first_name",
+	"givenname":     "person.first_name",
+	"lastname":      "person.last_name",
+	"surname":       "person.last_name",
+	"familyname":    "person.last_name",
+	"gender":        "person.gender",
+	"username":      "internet.user_name",
+	"email":         "internet.email",
+	"emailaddress":  "internet.email",
+	"jobtitle":      "job.title",
+	"job":           "job.title",
+	"city":          "address.city",
+	"state":         "address.state",
+	"country":       "address.country",
+	"countrycode":   "address.country_code",
+	"street":        "address.street_address",
+	"streetaddress": "address.street_address",
+	"address":       "address.street_address",
+	"zip":           "address.zip_code",
+	"zipcode":       "address.zip_code",
+	"postalcode":    "address.post_code",
+	"postcode":      "address.post_code",
+	"isbn":          "book.isbn",
+	"title":         "book.title",
+	"author":        "book.author",
+	"publisher":     "book.publisher",
+	"genre":         "book.genre",
+	"color":         "color.safe_name",
+	"currency":      "currency.code",
+	"currencycode":  "currency.code",
+	"useragent":     "user_agent",
+	"url":           "internet.url",
+	"website":       "internet.url",
+	"domain":        "internet.domain_name",
+	"ip":            "internet.ipv4",
+	"ipaddress":     "internet.ipv4",
+	"uuid":          "internet.uuid",
+	"id":            "internet.uuid",
+}
+
+func normalizeName(name string) string {
+	return strings.ToLower(strings.NewReplacer("_", "", "-", "", " ", "").Replace(name))
+}
+
+func (g *Generator) str(s map[string]interface{}, name string) (interface{}, error) {
+	var v string
+
+	format, _ := s["format"].(string)
+	pattern, _ := s["pattern"].(string)
+	minLength := int(number(s, "minLength", 0))
+	maxLength := int(number(s, "maxLength", -1))
+
+	if gen, ok := formats[format]; ok {
+		// Formats define the value too, values of the wrong length
+		// are drawn again rather than cut
+		for attempt := 0; attempt < maxFormatDraws; attempt++ {
+			v = gen(g.f)
+			if n := len([]rune(v)); n >= minLength && (maxLength < 0 || n <= maxLength) {
+				return v, nil
+			}
+		}
+		return nil, fmt.Errorf("error - no %s value fits lengths %d..%d", format, minLength, maxLength)
+	} else if pattern != "" {
+		var err error
+		if v, err = g.f.Regexify(pattern); err != nil {
+			return nil, err
+		}
+		// Patterns define the value, lengths can't be applied safely
+		return v, nil
+	} else if gen, ok := wellKnownNames[normalizeName(name)]; ok {
+		value, _ := g.f.Generate(gen)
+		v = fmt.Sprint(value)
+	} else {
+		v = g.f.Adjective()
+	}
+
+	for len([]rune(v)) < minLength {
+		v += " " + g.f.Adjective()
+	}
+	if maxLength >= 0 && len([]rune(v)) > maxLength {
+		v = strings.TrimSpace(string([]rune(v)[:maxLength]))
+		for len([]rune(v)) < minLength {
+			v += "x"
+		}
+	}
+
+	return v, nil
+}
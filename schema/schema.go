@@ -0,0 +1,100 @@
+// Generate documents conforming to a JSON Schema or OpenAPI component
+package schema
+
+import (
+	"fakery"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Default depth after which optional properties and array items
+// are no longer generated, and objects and arrays allowing another
+// type take it, which ends recursive schemas
+const DefaultMaxDepth = 6
+
+// Levels of required properties and items generated past MaxDepth
+// before a schema is deemed endlessly recursive
+const maxRequiredDepth = 32
+
+// Generator produces documents for the schemas of one document,
+// which is either a JSON Schema or a whole OpenAPI 3 spec.
+type Generator struct {
+	f        *fakery.Fakery
+	doc      interface{}
+	MaxDepth int
+}
+
+// Create a generator for a JSON Schema or OpenAPI document given
+// as JSON or YAML
+func New(f *fakery.Fakery, doc []byte) (*Generator, error) {
+	var root interface{}
+
+	if err := yaml.Unmarshal(doc, &root); err != nil {
+		return nil, fmt.Errorf("error - invalid schema document: %w", err)
+	}
+	if _, ok := root.(map[string]interface{}); !ok {
+		return nil, fmt.Errorf("error - schema document must be an object")
+	}
+
+	return &Generator{f: f, doc: root, MaxDepth: DefaultMaxDepth}, nil
+}
+
+// Generate a document for the root schema
+func (g *Generator) Generate() (interface{}, error) {
+	return g.generate(g.doc, "", 0)
+}
+
+// Generate a document for the schema at a reference such as
+// "#/components/schemas/User" or "#/$defs/address"
+func (g *Generator) GenerateRef(ref string) (interface{}, error) {
+	s, err := g.resolve(ref)
+	if err != nil {
+		return nil, err
+	}
+	return g.generate(s, refName(ref), 0)
+}
+
+// Generate a document for a JSON Schema in one call
+func Generate(f *fakery.Fakery, schema []byte) (interface{}, error) {
+	g, err := New(f, schema)
+	if err != nil {
+		return nil, err
+	}
+	return g.Generate()
+}
+
+// Resolve a local JSON pointer reference
+func (g *Generator) resolve(ref string) (interface{}, error) {
+	pointer, ok := strings.CutPrefix(ref, "#")
+	if !ok {
+		return nil, fmt.Errorf("error - only local references are supported, got %q", ref)
+	}
+
+	node := g.doc
+	if pointer == "" {
+		return node, nil
+	}
+
+	for _, token := range strings.Split(strings.TrimPrefix(pointer, "/"), "/") {
+		token, _ = url.PathUnescape(token)
+		token = strings.NewReplacer("~1", "/", "~0", "~").Replace(token)
+
+		m, ok := node.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("error - cannot resolve %q", ref)
+		}
+		if node, ok = m[token]; !ok {
+			return nil, fmt.Errorf("error - cannot resolve %q", ref)
+		}
+	}
+
+	return node, nil
+}
+
+// The last component of a reference, used as a name hint
+func refName(ref string) string {
+	return ref[strings.LastIndex(ref, "/")+1:]
+}
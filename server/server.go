@@ -0,0 +1,258 @@
+// HTTP server handing out fake data, for frontends whose backend
+// isn't ready yet
+package server
+
+import (
+	"encoding/json"
+	"fakery"
+	"fakery/schema"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// Largest page size a client may ask for
+const MaxCount = 1000
+
+// Server configuration
+type Config struct {
+	// Value of Access-Control-Allow-Origin, "*" if empty
+	CORSOrigin string
+	// Artificial latency added to every response, plus a
+	// random extra of up to Jitter
+	Latency time.Duration
+	Jitter  time.Duration
+	// Probability in [0, 1] that a request fails with a 5xx
+	ErrorRate float64
+}
+
+type server struct {
+	cfg Config
+}
+
+// Return the handler serving
+//
+//	GET  /v1                        list of generators
+//	GET  /v1/{generator}            e.g: /v1/person?count=10&seed=7&page=2&locale=en_GB
+//	POST /v1/schema                 documents for a JSON Schema in the body
+//
+// Latency and errors can also be injected per request with the
+// "latency" (e.g: 250ms) and "error_rate" query parameters.
+func New(cfg Config) http.Handler {
+	s := &server{cfg: cfg}
+	if s.cfg.CORSOrigin == "" {
+		s.cfg.CORSOrigin = "*"
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v1", s.list)
+	mux.HandleFunc("GET /v1/{generator}", s.generate)
+	mux.HandleFunc("POST /v1/schema", s.schema)
+
+	return s.middleware(mux)
+}
+
+// A page of generated data
+type page struct {
+	Generator string        `json:"generator,omitempty"`
+	Seed      int64         `json:"seed"`
+	Page      int           `json:"page"`
+	Count     int           `json:"count"`
+	Data      []interface{} `json:"data"`
+	Next      string        `json:"next,omitempty"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+func (s *server) middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Access-Control-Allow-Origin", s.cfg.CORSOrigin)
+		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, OPTIONS")
+		w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
+
+		if r.Method == http.MethodOptions {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		latency, errorRate, err := s.injection(r.URL.Query())
+		if err != nil {
+			writeJSON(w, http.StatusBadRequest, errorResponse{err.Error()})
+			return
+		}
+
+		time.Sleep(latency)
+		if errorRate > 0 && rand.Float64() < errorRate {
+			status := []int{http.StatusInternalServerError, http.StatusServiceUnavailable}[rand.IntN(2)]
+			writeJSON(w, status, errorResponse{"injected error"})
+			return
+		}
+
+		defer func() {
+			// Generators panic on locales lacking some data
+			if v := recover(); v != nil {
+				writeJSON(w, http.StatusInternalServerError, errorResponse{fmt.Sprint(v)})
+			}
+		}()
+		next.ServeHTTP(w, r)
+	})
+}
+
+// Latency and error rate for a request, from the config or
+// overridden by the query
+func (s *server) injection(query url.Values) (time.Duration, float64, error) {
+	latency := s.cfg.Latency
+	if s.cfg.Jitter > 0 {
+		latency += rand.N(s.cfg.Jitter)
+	}
+	errorRate := s.cfg.ErrorRate
+
+	if v := query.Get("latency"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil || d < 0 || d > time.Minute {
+			return 0, 0, fmt.Errorf("invalid latency %q", v)
+		}
+		latency = d
+	}
+	if v := query.Get("error_rate"); v != "" {
+		p, err := strconv.ParseFloat(v, 64)
+		if err != nil || p < 0 || p > 1 {
+			return 0, 0, fmt.Errorf("invalid error_rate %q", v)
+		}
+		errorRate = p
+	}
+
+	return latency, errorRate, nil
+}
+
+func (s *server) list(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, fakery.Generators())
+}
+
+// Parse the paging parameters. Without a seed a random one is
+// picked and returned, so later pages can be requested with it.
+func paging(query url.Values) (seed int64, pageNum, count int, err error) {
+	count, pageNum = 10, 1
+
+	if v := query.Get("count"); v != "" {
+		if count, err = strconv.Atoi(v); err != nil || count < 1 || count > MaxCount {
+			return 0, 0, 0, fmt.Errorf("count must be in 1..%d", MaxCount)
+		}
+	}
+	if v := query.Get("page"); v != "" {
+		if pageNum, err = strconv.Atoi(v); err != nil || pageNum < 1 {
+			return 0, 0, 0, fmt.Errorf("page must be a positive integer")
+		}
+	}
+
+	if v := query.Get("seed"); v != "" {
+		if seed, err = strconv.ParseInt(v, 10, 64); err != nil {
+			return 0, 0, 0, fmt.Errorf("invalid seed %q", v)
+		}
+	} else {
+		seed = fakery.New().Seed()
+	}
+
+	return seed, pageNum, count, nil
+}
+
+// Each page draws on a stream derived from the seed and the page
+// number, so a page is the same whichever pages came before it
+func pageFakery(query url.Values, seed int64, key string, pageNum int) *fakery.Fakery {
+	f := fakery.NewFromSeed(seed)
+	if locale := query.Get("locale"); locale != "" {
+		f.SetLocale(locale)
+	}
+	return f.Derive(key, pageNum)
+}
+
+func nextURL(r *http.Request, seed int64, pageNum int) string {
+	query := r.URL.Query()
+	query.Set("seed", strconv.FormatInt(seed, 10))
+	query.Set("page", strconv.Itoa(pageNum+1))
+	return r.URL.Path + "?" + query.Encode()
+}
+
+func (s *server) generate(w http.ResponseWriter, r *http.Request) {
+	name := r.PathValue("generator")
+	gen, ok := fakery.LookupGenerator(name)
+	if !ok {
+		writeJSON(w, http.StatusNotFound, errorResponse{fmt.Sprintf("unknown generator %q", name)})
+		return
+	}
+
+	query := r.URL.Query()
+	seed, pageNum, count, err := paging(query)
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{err.Error()})
+		return
+	}
+
+	f := pageFakery(query, seed, name, pageNum)
+	data := make([]interface{}, count)
+	for i := range data {
+		data[i] = gen(f)
+	}
+
+	writeJSON(w, http.StatusOK, page{
+		Generator: name,
+		Seed:      seed,
+		Page:      pageNum,
+		Count:     count,
+		Data:      data,
+		Next:      nextURL(r, seed, pageNum),
+	})
+}
+
+// Generate documents for the JSON Schema, or OpenAPI document with
+// a "ref" query parameter, posted in the body
+func (s *server) schema(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	seed, pageNum, count, err := paging(query)
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{err.Error()})
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, 1<<20))
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{err.Error()})
+		return
+	}
+
+	g, err := schema.New(pageFakery(query, seed, "schema", pageNum), body)
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{err.Error()})
+		return
+	}
+
+	data := make([]interface{}, count)
+	for i := range data {
+		if ref := query.Get("ref"); ref != "" {
+			data[i], err = g.GenerateRef(ref)
+		} else {
+			data[i], err = g.Generate()
+		}
+		if err != nil {
+			writeJSON(w, http.StatusUnprocessableEntity, errorResponse{err.Error()})
+			return
+		}
+	}
+
+	writeJSON(w, http.StatusOK, page{Seed: seed, Page: pageNum, Count: count, Data: data})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	encoder := json.NewEncoder(w)
+	encoder.SetEscapeHTML(false)
+	encoder.Encode(v)
+}
@@ -0,0 +1,81 @@
+// Saving and restoring the position of a Fakery in its random stream
+package fakery
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"math/rand/v2"
+)
+
+// Serialized form of a Fakery
+type snapshot struct {
+	Seed   int64  `json:"seed"`
+	Locale string `json:"locale"`
+	Source string `json:"source"`
+	State  []byte `json:"state"`
+}
+
+// Name of a snapshottable source, used to rebuild it on restore
+func sourceKind(src Source) string {
+	switch src.(type) {
+	case *rand.PCG:
+		return "pcg"
+	case *rand.ChaCha8:
+		return "chacha8"
+	}
+	return ""
+}
+
+// Snapshot captures the seed, locale and exact position of the
+// random stream. Pass the result to Restore to resume generation.
+// Only the PCG and ChaCha8 sources can be snapshotted.
+func (f *Fakery) Snapshot() ([]byte, error) {
+	kind := sourceKind(f.src)
+	if kind == "" {
+		return nil, fmt.Errorf("error - source %T does not support snapshots", f.src)
+	}
+
+	state, err := f.src.(encoding.BinaryMarshaler).MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(snapshot{
+		Seed:   f.seed,
+		Locale: f.locale,
+		Source: kind,
+		State:  state,
+	})
+}
+
+// Restore returns a Fakery which continues exactly where
+// the snapshotted instance was when Snapshot was called
+func Restore(b []byte) (*Fakery, error) {
+	var s snapshot
+	var src Source
+
+	if err := json.Unmarshal(b, &s); err != nil {
+		return nil, fmt.Errorf("error - invalid snapshot: %w", err)
+	}
+
+	switch s.Source {
+	case "pcg":
+		src = &rand.PCG{}
+	case "chacha8":
+		src = &rand.ChaCha8{}
+	default:
+		return nil, fmt.Errorf("error - unknown snapshot source %q", s.Source)
+	}
+
+	if err := src.(encoding.BinaryUnmarshaler).UnmarshalBinary(s.State); err != nil {
+		return nil, fmt.Errorf("error - invalid snapshot state: %w", err)
+	}
+
+	return &Fakery{
+		rng:    rand.New(src),
+		src:    src,
+		seed:   s.Seed,
+		locale: s.Locale,
+	}, nil
+}
@@ -0,0 +1,165 @@
+package synth
+
+import (
+	"encoding/csv"
+	"fakery"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"time"
+	"unicode/utf8"
+)
+
+// Times a string value is redrawn when it equals a sample value
+const maxRedraws = 20
+
+// Return the column names
+func (p *Profile) Header() []string {
+	header := make([]string, len(p.Columns))
+	for i, col := range p.Columns {
+		header[i] = col.Name
+	}
+	return header
+}
+
+// Return n rows of synthetic data following the profile. Every
+// column draws on its own stream derived from f, nulls are empty
+// strings. Strings are generated from the detected kind or the
+// shapes of the sample, within its lengths, and redrawn when they
+// equal a sample value; only the labels of categorical columns are
+// reused.
+func (p *Profile) Generate(f *fakery.Fakery, n int) [][]string {
+	rows := make([][]string, n)
+	for i := range rows {
+		rows[i] = make([]string, len(p.Columns))
+	}
+
+	for j, col := range p.Columns {
+		cf := f.Derive("synth", col.Name)
+
+		var seen map[string]bool
+		if j < len(p.seen) {
+			seen = p.seen[j]
+		}
+
+		for i := range rows {
+			if cf.Chance(col.NullRate) {
+				continue
+			}
+			rows[i][j] = col.value(cf, seen)
+		}
+	}
+
+	return rows
+}
+
+// Write a header and n rows of synthetic data as CSV
+func (p *Profile) WriteCSV(w io.Writer, f *fakery.Fakery, n int) error {
+	cw := csv.NewWriter(w)
+
+	if err := cw.Write(p.Header()); err != nil {
+		return err
+	}
+	if err := cw.WriteAll(p.Generate(f, n)); err != nil {
+		return err
+	}
+	return cw.Error()
+}
+
+// Return a value for the column
+func (c *Column) value(f *fakery.Fakery, seen map[string]bool) string {
+	if len(c.Categories) > 0 {
+		return pick(f, c.Categories)
+	}
+
+	switch c.Type {
+	case Int:
+		return strconv.FormatInt(int64(math.Round(c.quantile(f))), 10)
+	case Float:
+		return strconv.FormatFloat(c.quantile(f), 'f', c.Decimals, 64)
+	case Date:
+		return time.Unix(int64(c.quantile(f)), 0).UTC().Format(c.Layout)
+	}
+
+	var s string
+	for i := 0; i < maxRedraws; i++ {
+		s = c.str(f)
+		if c.fits(s) && !seen[s] {
+			return s
+		}
+	}
+
+	// The shapes hardly allow values outside the sample, so pad or
+	// cut the value to the sample lengths and change its characters
+	// until it is not a sample value
+	runes := []rune(s)
+	for len(runes) < c.MinLength || len(runes) == 0 {
+		runes = append(runes, randomChar(f))
+	}
+	if c.MaxLength > 0 && len(runes) > c.MaxLength {
+		runes = runes[:c.MaxLength]
+	}
+	for seen[string(runes)] {
+		if c.MaxLength == 0 || len(runes) < c.MaxLength {
+			runes = append(runes, randomChar(f))
+		} else {
+			runes[f.IntRange(len(runes))] = randomChar(f)
+		}
+	}
+	return string(runes)
+}
+
+// Report whether s has a length seen in the sample
+func (c *Column) fits(s string) bool {
+	n := utf8.RuneCountInString(s)
+	return n >= c.MinLength && (c.MaxLength == 0 || n <= c.MaxLength)
+}
+
+const alphanumerics = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789"
+
+// Return a random letter or digit
+func randomChar(f *fakery.Fakery) rune {
+	return rune(alphanumerics[f.IntRange(len(alphanumerics))])
+}
+
+// Draw a number from the distribution of the quantiles
+func (c *Column) quantile(f *fakery.Fakery) float64 {
+	q := c.Quantiles
+	if len(q) < 2 {
+		return c.Mean
+	}
+
+	pos := f.Float64() * float64(len(q)-1)
+	i := int(pos)
+	return q[i] + (q[i+1]-q[i])*(pos-float64(i))
+}
+
+func (c *Column) str(f *fakery.Fakery) string {
+	if c.Kind != "" {
+		if gen, ok := fakery.LookupGenerator(c.Kind); ok {
+			return fmt.Sprint(gen(f))
+		}
+	}
+	if len(c.Shapes) == 0 {
+		return ""
+	}
+
+	s, err := f.Regexify(pick(f, c.Shapes))
+	if err != nil {
+		return ""
+	}
+	return s
+}
+
+// Pick a value by frequency
+func pick(f *fakery.Fakery, cats []Category) string {
+	values := make([]string, len(cats))
+	weights := make([]float64, len(cats))
+	for i, c := range cats {
+		values[i], weights[i] = c.Value, c.Freq
+	}
+	// Frequencies of the sample are always positive
+	v, _ := fakery.WeightedPick(f, values, weights)
+	return v
+}
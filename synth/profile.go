@@ -0,0 +1,360 @@
+// Learn the shape of sample data and generate look-alike data
+package synth
+
+import (
+	"encoding/csv"
+	"fakery"
+	"fmt"
+	"io"
+	"math"
+	"net"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Type of the values of a column
+type Type string
+
+const (
+	Int    Type = "int"
+	Float  Type = "float"
+	Bool   Type = "bool"
+	Date   Type = "date"
+	String Type = "string"
+)
+
+// A value with its relative frequency
+type Category struct {
+	Value string  `json:"value"`
+	Freq  float64 `json:"freq"`
+}
+
+// Statistics of one column
+type Column struct {
+	Name     string  `json:"name"`
+	Type     Type    `json:"type"`
+	NullRate float64 `json:"null_rate"`
+	// Generator matching the values, e.g: internet.email
+	Kind string `json:"kind,omitempty"`
+	// Values of a low cardinality column
+	Categories []Category `json:"categories,omitempty"`
+
+	// Numbers and dates, dates as Unix seconds
+	Min    float64 `json:"min,omitempty"`
+	Max    float64 `json:"max,omitempty"`
+	Mean   float64 `json:"mean,omitempty"`
+	StdDev float64 `json:"std_dev,omitempty"`
+	// Evenly spaced quantiles from the minimum to the maximum
+	Quantiles []float64 `json:"quantiles,omitempty"`
+	// Decimal places of floats
+	Decimals int `json:"decimals,omitempty"`
+	// Layout of dates
+	Layout string `json:"layout,omitempty"`
+
+	// Strings
+	MinLength int `json:"min_length,omitempty"`
+	MaxLength int `json:"max_length,omitempty"`
+	// Regular expressions describing the values, e.g: [A-Z]{2}-[0-9]{3}
+	Shapes []Category `json:"shapes,omitempty"`
+}
+
+// Profile of sample data
+type Profile struct {
+	Rows    int       `json:"rows"`
+	Columns []*Column `json:"columns"`
+	// The sample values of each column, which are never generated
+	seen []map[string]bool
+}
+
+const (
+	// Most distinct values of a categorical column
+	maxCategories = 20
+	// Number of quantiles kept for numbers
+	numQuantiles = 21
+	// Most shapes kept for strings
+	maxShapes = 20
+	// Fraction of values which must match a kind
+	kindThreshold = 0.8
+)
+
+// Cells read as null, compared in lower case
+var nullValues = []string{"", "null", "nil", "na", "n/a", "none"}
+
+// Layouts tried for dates
+var dateLayouts = []string{time.RFC3339, "2006-01-02 15:04:05", "2006-01-02", "01/02/2006", "02.01.2006"}
+
+// Read a CSV file with a header row and profile its columns
+func ProfileCSV(r io.Reader) (*Profile, error) {
+	records, err := csv.NewReader(r).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("error - no header row")
+	}
+	return ProfileRows(records[0], records[1:]), nil
+}
+
+// Profile the columns of rows, named by header
+func ProfileRows(header []string, rows [][]string) *Profile {
+	p := &Profile{Rows: len(rows)}
+	f := fakery.New()
+
+	for i, name := range header {
+		var values []string
+		for _, row := range rows {
+			if i < len(row) && !isNull(row[i]) {
+				values = append(values, strings.TrimSpace(row[i]))
+			}
+		}
+
+		col := profileColumn(f, name, values)
+		if len(rows) > 0 {
+			col.NullRate = 1 - float64(len(values))/float64(len(rows))
+		}
+		p.Columns = append(p.Columns, col)
+
+		seen := make(map[string]bool)
+		for _, v := range values {
+			seen[v] = true
+		}
+		p.seen = append(p.seen, seen)
+	}
+
+	return p
+}
+
+func isNull(s string) bool {
+	s = strings.ToLower(strings.TrimSpace(s))
+	for _, n := range nullValues {
+		if s == n {
+			return true
+		}
+	}
+	return false
+}
+
+func profileColumn(f *fakery.Fakery, name string, values []string) *Column {
+	col := &Column{Name: name, Type: String}
+	if len(values) == 0 {
+		return col
+	}
+
+	col.Type, col.Layout = inferType(values)
+	if col.Type == String {
+		col.Kind = detectKind(f, values)
+	}
+
+	if col.Kind == "" {
+		col.Categories = categories(col.Type, values)
+	}
+
+	switch col.Type {
+	case Int, Float, Date:
+		profileNumbers(col, values)
+	case String:
+		profileStrings(col, values)
+	}
+
+	return col
+}
+
+// Return the narrowest type all values parse as
+func inferType(values []string) (Type, string) {
+	all := func(parse func(string) bool) bool {
+		for _, v := range values {
+			if !parse(v) {
+				return false
+			}
+		}
+		return true
+	}
+
+	switch {
+	case all(func(v string) bool { _, err := strconv.ParseInt(v, 10, 64); return err == nil }):
+		return Int, ""
+	case all(func(v string) bool { _, err := strconv.ParseFloat(v, 64); return err == nil }):
+		return Float, ""
+	case all(func(v string) bool { _, err := strconv.ParseBool(v); return err == nil }):
+		return Bool, ""
+	}
+
+	for _, layout := range dateLayouts {
+		if all(func(v string) bool { _, err := time.Parse(layout, v); return err == nil }) {
+			return Date, layout
+		}
+	}
+	return String, ""
+}
+
+var (
+	emailPattern = regexp.MustCompile(`^[^@\s]+@[^@\s]+\.[A-Za-z]{2,}$`)
+	uuidPattern  = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+)
+
+// Detectors of string kinds, by generator name
+var kinds = []struct {
+	generator string
+	match     func(f *fakery.Fakery, v string) bool
+}{
+	{"internet.email", func(f *fakery.Fakery, v string) bool { return emailPattern.MatchString(v) }},
+	{"internet.uuid", func(f *fakery.Fakery, v string) bool { return uuidPattern.MatchString(v) }},
+	{"internet.ipv4", func(f *fakery.Fakery, v string) bool { ip := net.ParseIP(v); return ip != nil && ip.To4() != nil }},
+	{"internet.ipv6", func(f *fakery.Fakery, v string) bool { ip := net.ParseIP(v); return ip != nil && ip.To4() == nil }},
+	{"internet.url", func(f *fakery.Fakery, v string) bool {
+		return strings.HasPrefix(v, "http://") || strings.HasPrefix(v, "https://")
+	}},
+	{"person.first_name", func(f *fakery.Fakery, v string) bool { return f.IsFirstName(v) }},
+	{"person.last_name", func(f *fakery.Fakery, v string) bool { return f.IsLastName(v) }},
+	{"person.name", func(f *fakery.Fakery, v string) bool {
+		words := strings.Fields(v)
+		return len(words) > 1 && f.IsFirstName(words[0]) && f.IsLastName(words[len(words)-1])
+	}},
+	{"address.country", func(f *fakery.Fakery, v string) bool { return f.IsCountry(v) }},
+}
+
+// Return the generator matching most values, if any
+func detectKind(f *fakery.Fakery, values []string) string {
+	for _, k := range kinds {
+		matches := 0
+		for _, v := range values {
+			if k.match(f, v) {
+				matches++
+			}
+		}
+		if float64(matches) >= kindThreshold*float64(len(values)) {
+			return k.generator
+		}
+	}
+	return ""
+}
+
+// Return the values with their frequencies if there are few
+// distinct values which repeat
+func categories(typ Type, values []string) []Category {
+	counts := make(map[string]int)
+	for _, v := range values {
+		counts[v]++
+	}
+
+	repeated := len(counts)*2 <= len(values)
+	if typ != Bool && (len(counts) > maxCategories || !repeated) {
+		return nil
+	}
+
+	return frequencies(counts, len(values), len(counts))
+}
+
+// Return the n most frequent values of counts
+func frequencies(counts map[string]int, total, n int) []Category {
+	var cats []Category
+	for v, c := range counts {
+		cats = append(cats, Category{Value: v, Freq: float64(c) / float64(total)})
+	}
+	sort.Slice(cats, func(i, j int) bool {
+		if cats[i].Freq != cats[j].Freq {
+			return cats[i].Freq > cats[j].Freq
+		}
+		return cats[i].Value < cats[j].Value
+	})
+
+	if len(cats) > n {
+		cats = cats[:n]
+	}
+	return cats
+}
+
+func profileNumbers(col *Column, values []string) {
+	nums := make([]float64, len(values))
+
+	for i, v := range values {
+		switch col.Type {
+		case Date:
+			t, _ := time.Parse(col.Layout, v)
+			nums[i] = float64(t.Unix())
+		default:
+			nums[i], _ = strconv.ParseFloat(v, 64)
+			if _, frac, ok := strings.Cut(v, "."); ok {
+				col.Decimals = fakery.MaxInt(col.Decimals, len(frac))
+			}
+		}
+	}
+	sort.Float64s(nums)
+
+	col.Min, col.Max = nums[0], nums[len(nums)-1]
+
+	var sum, sq float64
+	for _, n := range nums {
+		sum += n
+	}
+	col.Mean = sum / float64(len(nums))
+	for _, n := range nums {
+		sq += (n - col.Mean) * (n - col.Mean)
+	}
+	col.StdDev = math.Sqrt(sq / float64(len(nums)))
+
+	col.Quantiles = make([]float64, numQuantiles)
+	for i := range col.Quantiles {
+		// Linear interpolation between the closest ranks
+		pos := float64(i) / float64(numQuantiles-1) * float64(len(nums)-1)
+		lo := int(pos)
+		hi := fakery.MinInt(lo+1, len(nums)-1)
+		col.Quantiles[i] = nums[lo] + (nums[hi]-nums[lo])*(pos-float64(lo))
+	}
+}
+
+func profileStrings(col *Column, values []string) {
+	col.MinLength = math.MaxInt
+	shapes := make(map[string]int)
+
+	for _, v := range values {
+		n := utf8.RuneCountInString(v)
+		col.MinLength = fakery.MinInt(col.MinLength, n)
+		col.MaxLength = fakery.MaxInt(col.MaxLength, n)
+		shapes[shape(v)]++
+	}
+
+	col.Shapes = frequencies(shapes, len(values), maxShapes)
+}
+
+// Return a regular expression for the shape of s, with runs of
+// upper case letters, lower case letters and digits as classes,
+// e.g: "AB-123" gives [A-Z]{2}-[0-9]{3}
+func shape(s string) string {
+	var sb strings.Builder
+
+	class := func(r rune) string {
+		switch {
+		case unicode.IsUpper(r):
+			return "[A-Z]"
+		case unicode.IsLower(r):
+			return "[a-z]"
+		case unicode.IsDigit(r):
+			return "[0-9]"
+		}
+		return regexp.QuoteMeta(string(r))
+	}
+
+	runes := []rune(s)
+	for i := 0; i < len(runes); {
+		c := class(runes[i])
+		j := i + 1
+		for j < len(runes) && class(runes[j]) == c {
+			j++
+		}
+
+		// Classes and quoted literals such as \. are single atoms
+		sb.WriteString(c)
+		if n := j - i; n > 1 {
+			fmt.Fprintf(&sb, "{%d}", n)
+		}
+		i = j
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,137 @@
+package tests
+
+import (
+	"database/sql"
+	"fakery"
+	"fakery/anon"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestAnonDeterministic(t *testing.T) {
+	a := anon.New([]byte("secret"))
+
+	for _, kind := range anon.Kinds {
+		value := map[anon.Kind]string{
+			anon.Name:       "John Smith",
+			anon.Email:      "john.smith@acme.com",
+			anon.Address:    "1 Main Street, Springfield",
+			anon.CardNumber: "4111 1111 1111 1111",
+			anon.Phone:      "+1 415-555-2671",
+		}[kind]
+
+		fake, err := a.Replace(kind, value)
+		Expect(t, nil, err)
+		NotExpect(t, value, fake, kind)
+
+		again, _ := anon.New([]byte("secret")).Replace(kind, value)
+		Expect(t, fake, again, kind)
+
+		other, _ := anon.New([]byte("other key")).Replace(kind, value)
+		NotExpect(t, fake, other, kind)
+	}
+
+	// Spacing and case of the real value do not matter
+	x, _ := a.Replace(anon.Email, "John.Smith@ACME.com ")
+	y, _ := a.Replace(anon.Email, "john.smith@acme.com")
+	Expect(t, x, y)
+}
+
+func TestAnonFormat(t *testing.T) {
+	a := anon.New([]byte("secret"))
+	f := fakery.New()
+
+	for _, name := range []string{"John Smith", "Mary Jones", "Dr. John Smith"} {
+		real, _ := f.NameGender(strings.Fields(name)[len(strings.Fields(name))-2])
+		fake, _ := a.Replace(anon.Name, name)
+		// Some first names are listed for both genders
+		fakeGender, ok := f.NameGender(strings.Fields(fake)[len(strings.Fields(fake))-2])
+		Expect(t, true, !ok || fakeGender == real, fake)
+	}
+	fake, _ := a.Replace(anon.Name, "Dr. John Smith")
+	Expect(t, true, strings.HasPrefix(fake, "Dr. "))
+	fake, _ = a.Replace(anon.Name, "JOHN SMITH")
+	Expect(t, strings.ToUpper(fake), fake)
+
+	// Free mail stays free mail, company domains are shared
+	fake, _ = a.Replace(anon.Email, "someone@gmail.com")
+	Expect(t, true, f.IsFreeEmailDomain(fake[strings.Index(fake, "@")+1:]), fake)
+	x, _ := a.Replace(anon.Email, "alice@acme.com")
+	y, _ := a.Replace(anon.Email, "bob@acme.com")
+	NotExpect(t, x, y)
+	Expect(t, x[strings.Index(x, "@"):], y[strings.Index(y, "@"):])
+	Expect(t, false, f.IsFreeEmailDomain(x[strings.Index(x, "@")+1:]), x)
+
+	for _, card := range []string{"4111 1111 1111 1111", "378282246310005", "5555-5555-5555-4444", "4011780000000006"} {
+		fake, err := a.Replace(anon.CardNumber, card)
+		Expect(t, nil, err)
+		Expect(t, len(card), len(fake))
+		c := fakery.CreditCard{Number: strings.NewReplacer(" ", "", "-", "").Replace(fake)}
+		Expect(t, true, c.Validate(), fake)
+		Expect(t, fakery.CardNetworkFromNumber(card), fakery.CardNetworkFromNumber(fake), fake)
+	}
+	// Networks told apart by six digits, here Elo within VISA's 4
+	fake, _ = a.Replace(anon.CardNumber, "4011780000000006")
+	Expect(t, "401178", fake[:6])
+	_, err := a.Replace(anon.CardNumber, "1234")
+	NotExpect(t, nil, err)
+
+	fake, _ = a.Replace(anon.Phone, "+44 20 7946 0958")
+	Expect(t, true, regexp.MustCompile(`^\+44 \d{2} \d{4} \d{4}$`).MatchString(fake), fake)
+	fake, _ = a.Replace(anon.Phone, "020 7946 0958")
+	Expect(t, true, regexp.MustCompile(`^0\d{2} \d{4} \d{4}$`).MatchString(fake), fake)
+
+	_, err = a.Replace("ssn", "123-45-6789")
+	NotExpect(t, nil, err)
+	fake, _ = a.Replace(anon.Email, "")
+	Expect(t, "", fake)
+}
+
+type customerRecord struct {
+	ID      int
+	Name    string         `anon:"name"`
+	Email   *string        `anon:"email"`
+	Phones  []string       `anon:"phone"`
+	Card    sql.NullString `anon:"card_number"`
+	Note    string         `anon:"-"`
+	Contact struct {
+		Name string `anon:"name"`
+	}
+	Friends []*customerRecord
+}
+
+func TestAnonymize(t *testing.T) {
+	a := anon.New([]byte("secret"))
+
+	email := "mary.jones@example.org"
+	acc := customerRecord{
+		ID:      7,
+		Name:    "Mary Jones",
+		Email:   &email,
+		Phones:  []string{"+1 415-555-2671"},
+		Card:    sql.NullString{String: "4111111111111111", Valid: true},
+		Note:    "Mary Jones",
+		Friends: []*customerRecord{{Name: "Mary Jones"}},
+	}
+	acc.Contact.Name = "John Smith"
+
+	err := a.Anonymize(&acc)
+	Expect(t, nil, err)
+
+	name, _ := a.Replace(anon.Name, "Mary Jones")
+	Expect(t, name, acc.Name)
+	Expect(t, name, acc.Friends[0].Name)
+	Expect(t, 7, acc.ID)
+	Expect(t, "Mary Jones", acc.Note)
+	NotExpect(t, "mary.jones@example.org", *acc.Email)
+	NotExpect(t, "+1 415-555-2671", acc.Phones[0])
+	NotExpect(t, "4111111111111111", acc.Card.String)
+	NotExpect(t, "John Smith", acc.Contact.Name)
+
+	var bad struct {
+		Age int `anon:"name"`
+	}
+	NotExpect(t, nil, a.Anonymize(&bad))
+	NotExpect(t, nil, a.Anonymize(acc))
+}
@@ -0,0 +1,66 @@
+package tests
+
+import (
+	"fakery"
+	"testing"
+)
+
+func TestPeople(t *testing.T) {
+	people := fakery.New().People(10)
+	Expect(t, 10, len(people))
+	for _, p := range people {
+		Expect(t, true, len(p.Name) > 0)
+	}
+}
+
+func TestSeq(t *testing.T) {
+	count := 0
+	for email := range fakery.Seq(fakery.New(), (*fakery.Fakery).Email) {
+		Expect(t, true, len(email) > 0)
+		count++
+		if count == 5 {
+			break
+		}
+	}
+	Expect(t, 5, count)
+}
+
+func TestTake(t *testing.T) {
+	a := fakery.Take(fakery.NewFromSeed(3), 5, (*fakery.Fakery).Name)
+	b := fakery.Take(fakery.NewFromSeed(3), 5, (*fakery.Fakery).Name)
+	Expect(t, 5, len(a))
+	for i := range a {
+		Expect(t, a[i], b[i])
+	}
+
+	// gen runs exactly n times, so f is not advanced any further
+	calls := 0
+	count := func(f *fakery.Fakery) string {
+		calls++
+		return f.Name()
+	}
+	Expect(t, 0, len(fakery.Take(fakery.New(), 0, count)))
+	Expect(t, 0, calls)
+	fakery.Take(fakery.New(), 3, count)
+	Expect(t, 3, calls)
+
+	f := fakery.NewFromSeed(3)
+	f.People(2)
+	g := fakery.NewFromSeed(3)
+	g.Person()
+	g.Person()
+	Expect(t, g.Name(), f.Name())
+}
+
+func TestBatch(t *testing.T) {
+	a := fakery.Batch(fakery.NewFromSeed(5), 50, 1, (*fakery.Fakery).Person)
+	b := fakery.Batch(fakery.NewFromSeed(5), 50, 8, (*fakery.Fakery).Person)
+	Expect(t, 50, len(b))
+	for i := range a {
+		Expect(t, a[i].FullName, b[i].FullName)
+		Expect(t, a[i].Email, b[i].Email)
+	}
+
+	Expect(t, 0, len(fakery.Batch(fakery.New(), 0, 4, (*fakery.Fakery).Person)))
+	Expect(t, 0, len(fakery.Batch(fakery.New(), -1, 4, (*fakery.Fakery).Person)))
+}
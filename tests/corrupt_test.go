@@ -0,0 +1,162 @@
+package tests
+
+import (
+	"fakery"
+	"fmt"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestCorruptString(t *testing.T) {
+	f := fakery.NewFromSeed(42)
+
+	for _, kind := range []fakery.Corruption{fakery.CorruptTypo, fakery.CorruptWhitespace,
+		fakery.CorruptCasing, fakery.CorruptTruncate, fakery.CorruptEncoding} {
+		c := f.Corrupt(fakery.CorruptRates{kind: 1})
+
+		for i := 0; i < 50; i++ {
+			name := f.Name()
+			dirty := c.String(name)
+
+			for _, ev := range c.Log() {
+				Expect(t, kind, ev.Kind)
+			}
+			if dirty == name {
+				// e.g: casing a name which already is lower case
+				continue
+			}
+			ev := c.Log()[len(c.Log())-1]
+			Expect(t, i, ev.Record)
+			Expect(t, name, ev.Before)
+			Expect(t, dirty, ev.After)
+
+			switch kind {
+			case fakery.CorruptTypo:
+				diff := utf8.RuneCountInString(dirty) - utf8.RuneCountInString(name)
+				Expect(t, true, diff >= -1 && diff <= 1, dirty)
+			case fakery.CorruptWhitespace:
+				Expect(t, strings.Join(strings.Fields(name), " "), strings.Join(strings.Fields(dirty), " "))
+			case fakery.CorruptCasing:
+				Expect(t, true, strings.EqualFold(name, dirty))
+			case fakery.CorruptTruncate:
+				Expect(t, true, strings.HasPrefix(name, dirty))
+			case fakery.CorruptEncoding:
+				Expect(t, true, strings.ContainsRune(dirty, utf8.RuneError))
+			}
+		}
+	}
+
+	// Mojibake for non ASCII text
+	c := f.Corrupt(fakery.CorruptRates{fakery.CorruptEncoding: 1})
+	Expect(t, "JosÃ©", c.String("José"))
+
+	// Zero rates leave values alone
+	c = f.Corrupt(nil)
+	Expect(t, "John Smith", c.String("John Smith"))
+	Expect(t, 0, len(c.Log()))
+}
+
+func TestCorruptRecord(t *testing.T) {
+	f := fakery.NewFromSeed(7)
+
+	type order struct {
+		Name     string `json:"name"`
+		Email    string `json:"email"`
+		Quantity int    `json:"quantity"`
+		Address  struct {
+			City string `json:"city"`
+		} `json:"address"`
+	}
+
+	c := f.Corrupt(fakery.CorruptRates{fakery.CorruptSwap: 1, fakery.CorruptOutOfRange: 1})
+	o := order{Name: "John Smith", Email: "john@example.com", Quantity: 3}
+	o.Address.City = "Springfield"
+
+	err := c.Record(&o)
+	Expect(t, nil, err)
+	Expect(t, true, o.Quantity < 0 || o.Quantity == 3000)
+	Expect(t, 2, len(c.Log()))
+	Expect(t, fakery.CorruptOutOfRange, c.Log()[0].Kind)
+	Expect(t, "quantity", c.Log()[0].Field)
+	Expect(t, fakery.CorruptSwap, c.Log()[1].Kind)
+
+	values := o.Name + "," + o.Email + "," + o.Address.City
+	NotExpect(t, "John Smith,john@example.com,Springfield", values)
+	for _, v := range []string{"John Smith", "john@example.com", "Springfield"} {
+		Expect(t, true, strings.Contains(values, v))
+	}
+
+	NotExpect(t, nil, c.Record(o))
+}
+
+func TestCorruptCycle(t *testing.T) {
+	type node struct {
+		ID   int   `json:"id"`
+		Next *node `json:"next"`
+	}
+
+	c := fakery.NewFromSeed(7).Corrupt(fakery.CorruptRates{fakery.CorruptOutOfRange: 1})
+	a := &node{ID: 1}
+	a.Next = &node{ID: 2, Next: a}
+
+	Expect(t, nil, c.Record(a))
+	// Every node is corrupted once
+	Expect(t, 2, len(c.Log()))
+	Expect(t, "id", c.Log()[0].Field)
+	Expect(t, "next.id", c.Log()[1].Field)
+}
+
+func TestCorruptValue(t *testing.T) {
+	f := fakery.NewFromSeed(7)
+	c := f.Corrupt(fakery.CorruptRates{fakery.CorruptOutOfRange: 1, fakery.CorruptCasing: 1})
+
+	type code string
+	for _, v := range []interface{}{int32(5), int64(5), uint8(5), float32(1.5), 5, 1.5} {
+		dirty := c.Value(v)
+		Expect(t, fmt.Sprintf("%T", v), fmt.Sprintf("%T", dirty))
+		NotExpect(t, v, dirty)
+	}
+	Expect(t, fmt.Sprintf("%T", code("")), fmt.Sprintf("%T", c.Value(code("abc"))))
+}
+
+func TestCorruptSeq(t *testing.T) {
+	f := fakery.NewFromSeed(3)
+	c := f.Corrupt(fakery.CorruptRates{fakery.CorruptDuplicate: 0.5, fakery.CorruptTypo: 0.2})
+
+	var people []*fakery.Person
+	for p := range fakery.CorruptSeq(c, fakery.Seq(f, (*fakery.Fakery).Person)) {
+		if len(people) == 200 {
+			break
+		}
+		people = append(people, p)
+	}
+
+	dups, typos := 0, 0
+	for _, ev := range c.Log() {
+		if ev.Record >= len(people) {
+			// Made before the loop stopped
+			continue
+		}
+		switch ev.Kind {
+		case fakery.CorruptDuplicate:
+			dups++
+			Expect(t, people[ev.Record-1].Email, people[ev.Record].Email)
+			NotExpect(t, people[ev.Record-1], people[ev.Record])
+		case fakery.CorruptTypo:
+			typos++
+			NotExpect(t, "", ev.Field)
+		}
+	}
+	Expect(t, true, dups > 30 && dups < 100, dups)
+	Expect(t, true, typos > 0)
+
+	// Generators can be wrapped too
+	gen, _ := fakery.LookupGenerator("person.first_name")
+	c = f.Corrupt(fakery.CorruptRates{fakery.CorruptCasing: 1})
+	name := c.Generator(gen)(f).(string)
+	for _, ev := range c.Log() {
+		Expect(t, name, ev.After)
+		Expect(t, true, strings.EqualFold(ev.Before, name))
+	}
+}
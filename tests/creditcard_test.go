@@ -2,6 +2,7 @@ package tests
 
 import (
 	"fakery"
+	"slices"
 	"strconv"
 	"strings"
 	"testing"
@@ -22,6 +23,118 @@ func TestCreditCard(t *testing.T) {
 	year, _ := strconv.Atoi(items[1])
 	Expect(t, true, month >= 1 && month <= 12)
 	Expect(t, true, year > 25)
-	// Number should be invalid
-	//	Expect(t, false, c.Validate())
+	Expect(t, true, c.Validate(), c.Number)
+}
+
+func TestCreditCardNumber(t *testing.T) {
+	f := fakery.NewFromSeed(49)
+
+	lengths := map[string][]int{"AMEX": {15}, "Diners Club": {14}, "Maestro": {12, 13, 14, 15, 16, 17, 18, 19}}
+	for _, network := range fakery.CardNetworks {
+		for i := 0; i < 100; i++ {
+			number := f.CreditCardNumber(network)
+			c := fakery.CreditCard{Number: number}
+			Expect(t, true, c.Validate(), network, number)
+			Expect(t, network, fakery.CardNetworkFromNumber(number), number)
+			if want, ok := lengths[network]; ok {
+				Expect(t, true, slices.Contains(want, len(number)), network, number)
+			}
+		}
+	}
+
+	Expect(t, "unsupported card type", f.CreditCardNumber("Bogus"))
+	Expect(t, true, strings.HasPrefix(f.CreditCardNumber("diners club"), "3"))
+}
+
+func TestCardNetworkFromNumber(t *testing.T) {
+	cases := map[string]string{
+		"4111 1111 1111 1111": "VISA",
+		"4011780000000006":    "Elo",
+		"5555555555554444":    "MasterCard",
+		"2223003122003222":    "MasterCard",
+		"378282246310005":     "AMEX",
+		"6011111111111117":    "Discover",
+		"6521000000000007":    "RuPay",
+		"6200000000000005":    "UnionPay",
+		"3530111333300000":    "JCB",
+		"30569309025904":      "Diners Club",
+		"2200000000000004":    "Mir",
+		"675964982643":        "Maestro",
+		"9111111111111111":    "",
+		"411111111":           "",
+		"4111-abcd":           "",
+	}
+	for number, network := range cases {
+		Expect(t, network, fakery.CardNetworkFromNumber(number), number)
+	}
+}
+
+func TestFormatCardNumber(t *testing.T) {
+	Expect(t, "4111 1111 1111 1111", fakery.FormatCardNumber("4111111111111111"))
+	Expect(t, "3782 822463 10005", fakery.FormatCardNumber("378282246310005"))
+	Expect(t, "3056 930902 5904", fakery.FormatCardNumber("30569309025904"))
+	Expect(t, "6759 6498 2643 8453 123", fakery.FormatCardNumber("6759649826438453123"))
+	Expect(t, "4111 1111 1111 1111", fakery.FormatCardNumber("4111-1111-1111-1111"))
+}
+
+func TestCreditCardTestNumbers(t *testing.T) {
+	f := fakery.NewFromSeed(49)
+
+	for i := 0; i < 50; i++ {
+		c := f.CreditCardWith(fakery.WithTestNumber(), fakery.WithFormattedNumber())
+		Expect(t, true, c.Validate(), c.Number)
+		Expect(t, c.Type, fakery.CardNetworkFromNumber(c.Number), c.Number)
+		Expect(t, true, strings.Contains(c.Number, " "))
+
+		c = f.CreditCardWith(fakery.WithCardType("Mir"), fakery.WithTestNumber())
+		NotExpect(t, "Mir", c.Type)
+		NotExpect(t, "", f.CreditCardTestNumber(c.Type))
+	}
+
+	amex := []string{"378282246310005", "371449635398431", "378734493671000"}
+	Expect(t, true, slices.Contains(amex, f.CreditCardTestNumber("amex")))
+	Expect(t, "", f.CreditCardTestNumber("Elo"))
+}
+
+func TestCreditCardTracks(t *testing.T) {
+	f := fakery.NewFromSeed(50)
+
+	for i := 0; i < 50; i++ {
+		c := f.CreditCardWith(fakery.WithCardHolder("Jane Q. Public"))
+		Expect(t, c.Number[:6], c.BIN)
+		NotExpect(t, "", c.Issuer)
+		Expect(t, 2, len(c.IssuerCountry))
+		Expect(t, true, slices.Contains([]string{"Classic", "Gold", "Platinum"}, c.Level), c.Level)
+		Expect(t, true, slices.Contains([]string{"credit", "debit", "prepaid"}, c.Funding), c.Funding)
+
+		// The same BIN always has the same metadata
+		info, ok := fakery.LookupBIN(c.BIN + strings.Repeat("0", len(c.Number)-6))
+		Expect(t, true, ok)
+		Expect(t, c.BINInfo, info)
+
+		Expect(t, len(c.Number), len(c.MaskedNumber))
+		Expect(t, c.Number[len(c.Number)-4:], c.MaskedNumber[len(c.Number)-4:])
+
+		token := fakery.CreditCard{Number: c.TokenNumber}
+		NotExpect(t, c.Number, c.TokenNumber)
+		Expect(t, true, token.Validate(), c.TokenNumber)
+		Expect(t, c.Type, fakery.CardNetworkFromNumber(c.TokenNumber))
+		Expect(t, len(c.Number), len(c.TokenNumber))
+
+		expiry := c.ExpiryDate[3:] + c.ExpiryDate[:2]
+		Expect(t, true, strings.HasPrefix(c.Track1, "%B"+c.Number+"^PUBLIC/JANE Q^"+expiry+"201"), c.Track1)
+		Expect(t, true, strings.HasPrefix(c.Track2, ";"+c.Number+"="+expiry+"201"), c.Track2)
+		Expect(t, true, strings.HasSuffix(c.Track1, "?"))
+		Expect(t, c.Track1[strings.LastIndex(c.Track1, "^")+8:], c.Track2[strings.Index(c.Track2, "=")+8:])
+	}
+
+	Expect(t, "411111******1111", fakery.MaskCardNumber("4111 1111 1111 1111"))
+	Expect(t, "378282*****0005", fakery.MaskCardNumber("378282246310005"))
+
+	_, ok := fakery.LookupBIN("9999999999999999")
+	Expect(t, false, ok)
+	Expect(t, "", f.CreditCardToken("9999999999999999"))
+
+	info, _ := fakery.LookupBIN("2200000000000004")
+	Expect(t, "RU", info.IssuerCountry)
 }
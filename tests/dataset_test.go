@@ -0,0 +1,131 @@
+package tests
+
+import (
+	"encoding/json"
+	"fakery/dataset"
+	"strings"
+	"testing"
+)
+
+var shopSchema = `
+seed: 42
+tables:
+  orders:
+    per: users
+    min: 1
+    max: 10
+    columns:
+      id: seq(100)
+      user_id: ref(users.id)
+      amount: lognormal(3, 1)
+      status: oneof(new, paid, shipped)
+  users:
+    rows: 50
+    columns:
+      id: uuid
+      name: person.name
+      email: internet.email
+      age: int(18, 90)
+  reviews:
+    rows: 20
+    columns:
+      order_id: ref(orders.id)
+`
+
+func TestDataset(t *testing.T) {
+	s, err := dataset.Parse([]byte(shopSchema))
+	Expect(t, nil, err)
+
+	d, err := s.Generate()
+	Expect(t, nil, err)
+	Expect(t, "users", d.Tables[0].Name)
+	Expect(t, "orders", d.Tables[1].Name)
+
+	users := d.Table("users")
+	Expect(t, 50, len(users.Rows))
+	Expect(t, "email", users.Columns[2])
+
+	ids := map[interface{}]int{}
+	for _, row := range users.Rows {
+		ids[row[0]] = 0
+		age := row[3].(int)
+		Expect(t, true, age >= 18 && age <= 90)
+	}
+
+	orders := d.Table("orders")
+	Expect(t, 100, orders.Rows[0][0])
+	for _, row := range orders.Rows {
+		_, ok := ids[row[1]]
+		Expect(t, true, ok)
+		ids[row[1]]++
+	}
+	for _, n := range ids {
+		Expect(t, true, n >= 1 && n <= 10)
+	}
+
+	// Same seed, same dataset
+	d2, _ := s.Generate()
+	Expect(t, users.Rows[7][1], d2.Table("users").Rows[7][1])
+	Expect(t, len(orders.Rows), len(d2.Table("orders").Rows))
+}
+
+func TestDatasetStableColumns(t *testing.T) {
+	s1, _ := dataset.Parse([]byte(shopSchema))
+	s2, err := dataset.Parse([]byte(strings.Replace(shopSchema,
+		"      name: person.name\n", "      name: person.name\n      city: address.city\n", 1)))
+	Expect(t, nil, err)
+
+	d1, _ := s1.Generate()
+	d2, _ := s2.Generate()
+	Expect(t, d1.Table("users").Rows[3][2], d2.Table("users").Rows[3][3])
+}
+
+func TestDatasetJSON(t *testing.T) {
+	s, err := dataset.Parse([]byte(`{"seed": 1, "tables": {"books": {"rows": 3, "columns": {"title": "book.title", "isbn": "isbn"}}}}`))
+	Expect(t, nil, err)
+
+	d, err := s.Generate()
+	Expect(t, nil, err)
+
+	var sb strings.Builder
+	Expect(t, nil, d.Table("books").WriteCSV(&sb))
+	Expect(t, 4, len(strings.Split(strings.TrimSpace(sb.String()), "\n")))
+	Expect(t, true, strings.HasPrefix(sb.String(), "title,isbn\n"))
+
+	// Keys follow the columns, not their alphabetical order
+	sb.Reset()
+	Expect(t, nil, d.Table("books").WriteJSONL(&sb))
+	lines := strings.Split(strings.TrimSpace(sb.String()), "\n")
+	Expect(t, 3, len(lines))
+	for _, line := range lines {
+		Expect(t, true, strings.HasPrefix(line, `{"title":"`), line)
+		Expect(t, true, strings.Contains(line, `","isbn":"`), line)
+		Expect(t, true, json.Valid([]byte(line)), line)
+	}
+}
+
+func TestDatasetErrors(t *testing.T) {
+	bad := []string{
+		`tables: {a: {rows: 1, columns: {x: nope.nope}}}`,
+		`tables: {a: {rows: 1, columns: {x: ref(b.id)}}}`,
+		`tables: {a: {rows: 1, columns: {x: int(1)}}}`,
+		`tables: {a: {per: b, columns: {x: seq}}}`,
+		`tables: {a: {columns: {x: seq}}}`,
+		`tables: {a: {rows: 0, columns: {x: seq}}}`,
+		`tables: {a: {rows: 1, columns: {x: seq}}, b: {per: a, columns: {y: seq}}}`,
+		`tables: {a: {rows: 1, columns: {x: seq}}, b: {per: a, min: 2, columns: {y: seq}}}`,
+		`tables: {a: {rows: 1, columns: {x: seq}}, b: {per: a, min: 3, max: 2, columns: {y: seq}}}`,
+		`tables: {}`,
+		`tables: {a: {rows: 1, columns: {x: "int(10, 1)"}}}`,
+		`tables: {a: {rows: 1, columns: {x: "float(1, 0.5)"}}}`,
+	}
+	for _, schema := range bad {
+		_, err := dataset.Parse([]byte(schema))
+		NotExpect(t, nil, err, schema)
+	}
+
+	s, err := dataset.Parse([]byte(`tables: {a: {rows: 1, columns: {x: ref(b.x)}}, b: {rows: 1, columns: {x: ref(a.x)}}}`))
+	Expect(t, nil, err)
+	_, err = s.Generate()
+	NotExpect(t, nil, err)
+}
@@ -0,0 +1,22 @@
+package tests
+
+import (
+	"fakery"
+	"testing"
+)
+
+func TestDerive(t *testing.T) {
+	f := fakery.NewFromSeed(42)
+	name := f.Derive("orders", 17).Name()
+
+	// Consuming the parent stream must not affect derived children
+	g := fakery.NewFromSeed(42)
+	for i := 0; i < 10; i++ {
+		g.FirstName()
+	}
+	Expect(t, name, g.Derive("orders", 17).Name())
+
+	// Different keys give different streams
+	NotExpect(t, f.Derive("orders", 17).Seed(), f.Derive("orders", 18).Seed())
+	NotExpect(t, f.Derive("orders", 17).Seed(), f.Derive("users", 17).Seed())
+}
@@ -0,0 +1,88 @@
+package tests
+
+import (
+	"encoding/csv"
+	"fakery"
+	"fakery/export"
+	"slices"
+	"strings"
+	"testing"
+)
+
+type order struct {
+	ID       int            `json:"id"`
+	Customer *fakery.Person `json:"customer"`
+	Address  fakery.Address `json:"address"`
+	Note     *string        `json:"note,omitempty"`
+	Secret   string         `json:"-"`
+}
+
+func TestCSVWriter(t *testing.T) {
+	var sb strings.Builder
+
+	w := export.NewCSVWriter(&sb)
+	for _, p := range fakery.New().People(3) {
+		Expect(t, nil, w.Write(p))
+	}
+	Expect(t, nil, w.Flush())
+
+	rows, err := csv.NewReader(strings.NewReader(sb.String())).ReadAll()
+	Expect(t, nil, err)
+	Expect(t, 4, len(rows))
+	Expect(t, "name", rows[0][0])
+	Expect(t, "full_name", rows[0][1])
+}
+
+func TestCSVNested(t *testing.T) {
+	var sb strings.Builder
+
+	f := fakery.New()
+	w := export.NewCSVWriter(&sb)
+	Expect(t, nil, w.Write(order{ID: 1, Customer: f.Person(), Address: *f.Address(), Secret: "x"}))
+	Expect(t, nil, w.Flush())
+
+	rows, _ := csv.NewReader(strings.NewReader(sb.String())).ReadAll()
+	header := strings.Join(rows[0], ",")
+	Expect(t, true, strings.HasPrefix(header, "id,customer.name,"))
+	Expect(t, true, strings.Contains(header, ",address.city,"))
+	Expect(t, true, strings.HasSuffix(header, ",note"))
+	Expect(t, false, strings.Contains(header, "Secret"))
+}
+
+func TestCSVColumns(t *testing.T) {
+	var sb strings.Builder
+
+	w := export.NewTSVWriter(&sb, export.WithColumns("year", "make"))
+	cars := fakery.Take(fakery.New(), 2, (*fakery.Fakery).Car)
+	Expect(t, nil, export.WriteCSV(w, slices.Values(cars)))
+
+	lines := strings.Split(strings.TrimSpace(sb.String()), "\n")
+	Expect(t, 3, len(lines))
+	Expect(t, "year\tmake", lines[0])
+}
+
+func TestCSVUnknownColumn(t *testing.T) {
+	var sb strings.Builder
+
+	w := export.NewCSVWriter(&sb, export.WithColumns("nope"))
+	NotExpect(t, nil, w.Write(fakery.New().Book()))
+}
+
+type node struct {
+	Name string `json:"name"`
+	Next *node  `json:"next"`
+}
+
+func TestCSVRecursiveType(t *testing.T) {
+	var sb strings.Builder
+
+	w := export.NewCSVWriter(&sb)
+	Expect(t, nil, w.Write(node{Name: "a", Next: &node{Name: "b"}}))
+	Expect(t, nil, w.Write(node{Name: "c"}))
+	Expect(t, nil, w.Flush())
+
+	rows, _ := csv.NewReader(strings.NewReader(sb.String())).ReadAll()
+	Expect(t, "name,next", strings.Join(rows[0], ","))
+	Expect(t, `{"name":"b","next":null}`, rows[1][1])
+	Expect(t, "", rows[2][1])
+}
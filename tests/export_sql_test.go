@@ -0,0 +1,123 @@
+package tests
+
+import (
+	"fakery"
+	"fakery/export"
+	"math"
+	"strings"
+	"testing"
+)
+
+type account struct {
+	ID     int     `json:"id"`
+	Name   string  `json:"name"`
+	Active bool    `json:"active"`
+	Note   *string `json:"note"`
+	Score  float64 `json:"score"`
+}
+
+func TestSQLInsert(t *testing.T) {
+	var sb strings.Builder
+
+	w := export.NewSQLWriter(&sb, "accounts", export.Postgres, export.WithBatchSize(2))
+	Expect(t, nil, w.Write(account{ID: 1, Name: "O'Brien", Active: true, Score: 1.5}))
+	Expect(t, nil, w.Write(account{ID: 2, Name: "Smith"}))
+	Expect(t, nil, w.Write(account{ID: 3, Name: "Jones"}))
+	Expect(t, nil, w.Flush())
+
+	out := sb.String()
+	Expect(t, 2, strings.Count(out, "INSERT INTO \"accounts\" (\"id\", \"name\", \"active\", \"note\", \"score\") VALUES"))
+	Expect(t, true, strings.Contains(out, "(1, 'O''Brien', TRUE, NULL, 1.5)"))
+	Expect(t, true, strings.Contains(out, "(3, 'Jones', FALSE, NULL, 0);"))
+}
+
+func TestSQLMySQL(t *testing.T) {
+	var sb strings.Builder
+
+	w := export.NewSQLWriter(&sb, "accounts", export.MySQL)
+	Expect(t, nil, w.Write(account{ID: 1, Name: `a\b`, Active: true}))
+	Expect(t, nil, w.Flush())
+	Expect(t, true, strings.Contains(sb.String(), "`accounts`"))
+	Expect(t, true, strings.Contains(sb.String(), `(1, 'a\\b', 1, NULL, 0)`))
+}
+
+func TestSQLCopy(t *testing.T) {
+	var sb strings.Builder
+
+	w := export.NewSQLWriter(&sb, "accounts", export.Postgres, export.WithCopy())
+	Expect(t, nil, w.Write(account{ID: 1, Name: "tab\there"}))
+	Expect(t, nil, w.Flush())
+
+	lines := strings.Split(sb.String(), "\n")
+	Expect(t, `COPY "accounts" ("id", "name", "active", "note", "score") FROM STDIN;`, lines[0])
+	Expect(t, `1	tab\there	false	\N	0`, lines[1])
+	Expect(t, `\.`, lines[2])
+
+	w = export.NewSQLWriter(&sb, "accounts", export.SQLite, export.WithCopy())
+	NotExpect(t, nil, w.Write(account{}))
+}
+
+type measure struct {
+	ID    int     `json:"id"`
+	Value float64 `json:"value"`
+	Ratio float32 `json:"ratio"`
+}
+
+func TestSQLFloats(t *testing.T) {
+	var sb strings.Builder
+
+	w := export.NewSQLWriter(&sb, "measures", export.Postgres)
+	Expect(t, nil, w.Write(measure{ID: 1, Value: math.NaN(), Ratio: 0.1}))
+	Expect(t, nil, w.Write(measure{ID: 2, Value: math.Inf(1), Ratio: float32(math.Inf(-1))}))
+	Expect(t, nil, w.Flush())
+	Expect(t, true, strings.Contains(sb.String(), "(1, NULL, 0.1)"), sb.String())
+	Expect(t, true, strings.Contains(sb.String(), "(2, NULL, NULL)"), sb.String())
+
+	// Columns of floats accept the NULL written for NaN
+	sb.Reset()
+	w = export.NewSQLWriter(&sb, "measures", export.Postgres, export.WithCreateTable())
+	Expect(t, nil, w.Write(measure{ID: 1, Value: math.NaN(), Ratio: 0.1}))
+	Expect(t, nil, w.Flush())
+	Expect(t, true, strings.Contains(sb.String(), `"id" BIGINT NOT NULL,`), sb.String())
+	Expect(t, true, strings.Contains(sb.String(), `"value" DOUBLE PRECISION,`), sb.String())
+	Expect(t, true, strings.Contains(sb.String(), "(1, NULL, 0.1)"), sb.String())
+
+	sb.Reset()
+	w = export.NewSQLWriter(&sb, "measures", export.Postgres, export.WithCopy())
+	Expect(t, nil, w.Write(measure{ID: 1, Value: math.Inf(-1), Ratio: 0.1}))
+	Expect(t, nil, w.Flush())
+	Expect(t, `1	\N	0.1`, strings.Split(sb.String(), "\n")[1])
+}
+
+func TestSQLCreateTable(t *testing.T) {
+	ddl, err := export.CreateTable("accounts", export.SQLite, account{})
+	Expect(t, nil, err)
+	Expect(t, true, strings.Contains(ddl, `"id" INTEGER NOT NULL`))
+	Expect(t, true, strings.Contains(ddl, `"note" TEXT,`))
+	Expect(t, true, strings.Contains(ddl, "\"score\" REAL\n"), ddl)
+
+	var sb strings.Builder
+	w := export.NewSQLWriter(&sb, "people", export.Postgres, export.WithCreateTable())
+	Expect(t, nil, w.Write(fakery.New().Person()))
+	Expect(t, nil, w.Flush())
+	Expect(t, true, strings.HasPrefix(sb.String(), `CREATE TABLE "people" (`))
+}
+
+func TestParseDialect(t *testing.T) {
+	d, err := export.ParseDialect("postgresql")
+	Expect(t, nil, err)
+	Expect(t, export.Postgres, d)
+	_, err = export.ParseDialect("oracle")
+	NotExpect(t, nil, err)
+}
+
+func TestSQLRow(t *testing.T) {
+	var sb strings.Builder
+
+	w := export.NewSQLWriter(&sb, "t", export.Postgres, export.WithCreateTable())
+	Expect(t, nil, w.Write(export.Row{Columns: []string{"id", "name"}, Values: []interface{}{1, nil}}))
+	NotExpect(t, nil, w.Write(export.Row{Columns: []string{"id"}, Values: nil}))
+	Expect(t, nil, w.Flush())
+	Expect(t, true, strings.Contains(sb.String(), `"id" BIGINT,`))
+	Expect(t, true, strings.Contains(sb.String(), "(1, NULL);"))
+}
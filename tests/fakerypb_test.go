@@ -0,0 +1,234 @@
+package tests
+
+import (
+	"fakery"
+	"fakery/fakerypb"
+	"net"
+	"net/mail"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// Build a test descriptor equivalent to
+//
+//	enum Status { STATUS_UNSPECIFIED = 0; ACTIVE = 1; BLOCKED = 2; }
+//	message User {
+//	  string email = 1;
+//	  string first_name = 2;
+//	  string postal_code = 3;
+//	  int32 age = 4;
+//	  Status status = 5;
+//	  repeated string tags = 6;
+//	  map<string, int64> scores = 7;
+//	  oneof contact { string phone = 8; string website = 9; }
+//	  google.protobuf.Timestamp created_at = 10;
+//	  google.protobuf.Duration ttl = 11;
+//	  User manager = 12;
+//	  google.protobuf.StringValue last_name = 13;
+//	}
+func userDescriptor(t *testing.T) protoreflect.MessageDescriptor {
+	t.Helper()
+
+	field := func(name string, num int32, typ descriptorpb.FieldDescriptorProto_Type) *descriptorpb.FieldDescriptorProto {
+		return &descriptorpb.FieldDescriptorProto{
+			Name:     proto.String(name),
+			JsonName: proto.String(name),
+			Number:   proto.Int32(num),
+			Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+			Type:     typ.Enum(),
+		}
+	}
+	message := func(name string, num int32, typeName string) *descriptorpb.FieldDescriptorProto {
+		fd := field(name, num, descriptorpb.FieldDescriptorProto_TYPE_MESSAGE)
+		fd.TypeName = proto.String(typeName)
+		return fd
+	}
+
+	tags := field("tags", 6, descriptorpb.FieldDescriptorProto_TYPE_STRING)
+	tags.Label = descriptorpb.FieldDescriptorProto_LABEL_REPEATED.Enum()
+	scores := message("scores", 7, ".test.User.ScoresEntry")
+	scores.Label = descriptorpb.FieldDescriptorProto_LABEL_REPEATED.Enum()
+	status := field("status", 5, descriptorpb.FieldDescriptorProto_TYPE_ENUM)
+	status.TypeName = proto.String(".test.Status")
+	phone := field("phone", 8, descriptorpb.FieldDescriptorProto_TYPE_STRING)
+	phone.OneofIndex = proto.Int32(0)
+	website := field("website", 9, descriptorpb.FieldDescriptorProto_TYPE_STRING)
+	website.OneofIndex = proto.Int32(0)
+
+	file := &descriptorpb.FileDescriptorProto{
+		Name:       proto.String("test/user.proto"),
+		Package:    proto.String("test"),
+		Syntax:     proto.String("proto3"),
+		Dependency: []string{"google/protobuf/timestamp.proto", "google/protobuf/duration.proto", "google/protobuf/wrappers.proto"},
+		EnumType: []*descriptorpb.EnumDescriptorProto{{
+			Name: proto.String("Status"),
+			Value: []*descriptorpb.EnumValueDescriptorProto{
+				{Name: proto.String("STATUS_UNSPECIFIED"), Number: proto.Int32(0)},
+				{Name: proto.String("ACTIVE"), Number: proto.Int32(1)},
+				{Name: proto.String("BLOCKED"), Number: proto.Int32(2)},
+			},
+		}},
+		MessageType: []*descriptorpb.DescriptorProto{{
+			Name: proto.String("User"),
+			Field: []*descriptorpb.FieldDescriptorProto{
+				field("email", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING),
+				field("first_name", 2, descriptorpb.FieldDescriptorProto_TYPE_STRING),
+				field("postal_code", 3, descriptorpb.FieldDescriptorProto_TYPE_STRING),
+				field("age", 4, descriptorpb.FieldDescriptorProto_TYPE_INT32),
+				status, tags, scores, phone, website,
+				message("created_at", 10, ".google.protobuf.Timestamp"),
+				message("ttl", 11, ".google.protobuf.Duration"),
+				message("manager", 12, ".test.User"),
+				message("last_name", 13, ".google.protobuf.StringValue"),
+			},
+			OneofDecl: []*descriptorpb.OneofDescriptorProto{{Name: proto.String("contact")}},
+			NestedType: []*descriptorpb.DescriptorProto{{
+				Name: proto.String("ScoresEntry"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					field("key", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING),
+					field("value", 2, descriptorpb.FieldDescriptorProto_TYPE_INT64),
+				},
+				Options: &descriptorpb.MessageOptions{MapEntry: proto.Bool(true)},
+			}},
+		}},
+	}
+
+	fd, err := protodesc.NewFile(file, protoregistry.GlobalFiles)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return fd.Messages().ByName("User")
+}
+
+func TestProtoFill(t *testing.T) {
+	md := userDescriptor(t)
+	f := fakery.NewFromSeed(40)
+
+	for i := 0; i < 20; i++ {
+		msg := dynamicpb.NewMessage(md)
+		err := fakerypb.Fill(f, msg, nil)
+		Expect(t, nil, err)
+
+		fields := md.Fields()
+		_, err = mail.ParseAddress(msg.Get(fields.ByName("email")).String())
+		Expect(t, nil, err)
+		NotExpect(t, "", msg.Get(fields.ByName("first_name")).String())
+		NotExpect(t, "", msg.Get(fields.ByName("postal_code")).String())
+		NotExpect(t, protoreflect.EnumNumber(0), msg.Get(fields.ByName("status")).Enum())
+
+		tags := msg.Get(fields.ByName("tags")).List().Len()
+		Expect(t, true, tags >= 1 && tags <= 3)
+		Expect(t, true, msg.Get(fields.ByName("scores")).Map().Len() >= 1)
+
+		// Exactly one member of the oneof is set
+		Expect(t, true, msg.WhichOneof(md.Oneofs().ByName("contact")) != nil)
+		Expect(t, false, msg.Has(fields.ByName("phone")) && msg.Has(fields.ByName("website")))
+
+		ts := &timestamppb.Timestamp{}
+		Expect(t, nil, convert(msg.Get(fields.ByName("created_at")).Message(), ts))
+		Expect(t, nil, ts.CheckValid())
+		Expect(t, true, ts.Seconds > 0)
+		// Drawn before a fixed date, not relative to now
+		Expect(t, true, ts.AsTime().Year() >= 2020 && ts.AsTime().Year() < 2025, ts.AsTime())
+		ttl := &durationpb.Duration{}
+		Expect(t, nil, convert(msg.Get(fields.ByName("ttl")).Message(), ttl))
+		Expect(t, true, ttl.AsDuration() > 0)
+		name := &wrapperspb.StringValue{}
+		Expect(t, nil, convert(msg.Get(fields.ByName("last_name")).Message(), name))
+		NotExpect(t, "", name.Value)
+	}
+}
+
+func TestProtoFillDepth(t *testing.T) {
+	md := userDescriptor(t)
+	manager := md.Fields().ByName("manager")
+
+	depth := func(m protoreflect.Message) int {
+		n := 0
+		for m.Has(manager) {
+			m = m.Get(manager).Message()
+			n++
+		}
+		return n
+	}
+
+	msg := dynamicpb.NewMessage(md)
+	err := fakerypb.Fill(fakery.NewFromSeed(1), msg, nil)
+	Expect(t, nil, err)
+	Expect(t, 4, depth(msg))
+
+	msg = dynamicpb.NewMessage(md)
+	err = fakerypb.Fill(fakery.NewFromSeed(1), msg, &fakerypb.Options{MaxDepth: 1})
+	Expect(t, nil, err)
+	Expect(t, 1, depth(msg))
+}
+
+func TestProtoFillGenerators(t *testing.T) {
+	md := userDescriptor(t)
+	msg := dynamicpb.NewMessage(md)
+
+	opts := &fakerypb.Options{Generators: map[string]string{"postal_code": "internet.ipv4"}}
+	err := fakerypb.Fill(fakery.NewFromSeed(2), msg, opts)
+	Expect(t, nil, err)
+	Expect(t, true, net.ParseIP(msg.Get(md.Fields().ByName("postal_code")).String()).To4() != nil)
+
+	// Same seed, same message
+	other := dynamicpb.NewMessage(md)
+	fakerypb.Fill(fakery.NewFromSeed(2), other, opts)
+	Expect(t, true, proto.Equal(msg, other))
+}
+
+func TestProtoFillZeroEnum(t *testing.T) {
+	// enum Mode { option allow_alias = true; MODE_UNSPECIFIED = 0; MODE_DEFAULT = 0; }
+	// message Config { Mode mode = 1; }
+	file := &descriptorpb.FileDescriptorProto{
+		Name:    proto.String("test/config.proto"),
+		Package: proto.String("test"),
+		Syntax:  proto.String("proto3"),
+		EnumType: []*descriptorpb.EnumDescriptorProto{{
+			Name: proto.String("Mode"),
+			Value: []*descriptorpb.EnumValueDescriptorProto{
+				{Name: proto.String("MODE_UNSPECIFIED"), Number: proto.Int32(0)},
+				{Name: proto.String("MODE_DEFAULT"), Number: proto.Int32(0)},
+			},
+			Options: &descriptorpb.EnumOptions{AllowAlias: proto.Bool(true)},
+		}},
+		MessageType: []*descriptorpb.DescriptorProto{{
+			Name: proto.String("Config"),
+			Field: []*descriptorpb.FieldDescriptorProto{{
+				Name:     proto.String("mode"),
+				JsonName: proto.String("mode"),
+				Number:   proto.Int32(1),
+				Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+				Type:     descriptorpb.FieldDescriptorProto_TYPE_ENUM.Enum(),
+				TypeName: proto.String(".test.Mode"),
+			}},
+		}},
+	}
+
+	fd, err := protodesc.NewFile(file, protoregistry.GlobalFiles)
+	Expect(t, nil, err)
+	md := fd.Messages().ByName("Config")
+
+	msg := dynamicpb.NewMessage(md)
+	Expect(t, nil, fakerypb.Fill(fakery.New(), msg, nil))
+	Expect(t, protoreflect.EnumNumber(0), msg.Get(md.Fields().ByName("mode")).Enum())
+}
+
+// Copy a dynamic message into a concrete one
+func convert(src protoreflect.Message, dst proto.Message) error {
+	b, err := proto.Marshal(src.Interface())
+	if err != nil {
+		return err
+	}
+	return proto.Unmarshal(b, dst)
+}
@@ -0,0 +1,75 @@
+package tests
+
+import (
+	"fakery/fakerytest"
+	"flag"
+	"fmt"
+	"testing"
+)
+
+// Records what the helpers do with a test
+type fakeTB struct {
+	name     string
+	failed   bool
+	logs     []string
+	cleanups []func()
+}
+
+func (f *fakeTB) Helper()           {}
+func (f *fakeTB) Name() string      { return f.name }
+func (f *fakeTB) Failed() bool      { return f.failed }
+func (f *fakeTB) Cleanup(fn func()) { f.cleanups = append(f.cleanups, fn) }
+func (f *fakeTB) Logf(format string, args ...interface{}) {
+	f.logs = append(f.logs, fmt.Sprintf(format, args...))
+}
+func (f *fakeTB) Fatalf(format string, args ...interface{}) {
+	f.failed = true
+	f.Logf(format, args...)
+}
+
+func (f *fakeTB) finish() {
+	for _, fn := range f.cleanups {
+		fn()
+	}
+}
+
+func TestFakerytestSeedLogging(t *testing.T) {
+	passing := &fakeTB{name: "TestPass"}
+	fakerytest.New(passing)
+	passing.finish()
+	Expect(t, 0, len(passing.logs))
+
+	failing := &fakeTB{name: "TestFail"}
+	fakerytest.New(failing)
+	failing.failed = true
+	failing.finish()
+	Expect(t, 1, len(failing.logs))
+
+	seed, err := fakerytest.Seed()
+	Expect(t, nil, err)
+	Expect(t, fmt.Sprintf("fakery seed: %d, reproduce with -fakery.seed=%d or FAKERY_SEED=%d", seed, seed, seed), failing.logs[0])
+}
+
+func TestFakerytestSubtests(t *testing.T) {
+	names := map[string]string{}
+	for _, sub := range []string{"a", "b"} {
+		t.Run(sub, func(t *testing.T) {
+			names[sub] = fakerytest.New(t).Name()
+			// Same test name, same stream
+			Expect(t, names[sub], fakerytest.New(t).Name())
+		})
+	}
+	NotExpect(t, names["a"], names["b"])
+}
+
+func TestFakerytestGolden(t *testing.T) {
+	f := fakerytest.NewFromSeed(t, 1)
+	fakerytest.GoldenJSON(t, "person", f.Person())
+
+	if flag.Lookup("fakery.update").Value.String() == "true" {
+		return
+	}
+	tb := &fakeTB{name: t.Name()}
+	fakerytest.Golden(tb, "person", []byte("something else"))
+	Expect(t, true, tb.failed)
+}
@@ -0,0 +1,68 @@
+package tests
+
+import (
+	"database/sql"
+	"fakery"
+	"testing"
+)
+
+type customer struct {
+	Name    string         `fake:"person.name"`
+	Email   *string        `fake:"email,null=1"`
+	Phone   sql.NullString `fake:"first_name,null=1"`
+	City    sql.NullString `fake:"city"`
+	Year    int            `fake:"book.year"`
+	Car     *fakery.Car    `fake:"car"`
+	Address fakery.Address `fake:"address"`
+	Skip    string         `fake:"-"`
+	Nested  struct {
+		Title string `fake:"job.title"`
+	}
+}
+
+func TestFill(t *testing.T) {
+	var c customer
+
+	err := fakery.New().Fill(&c)
+	Expect(t, nil, err)
+	Expect(t, true, len(c.Name) > 0)
+	Expect(t, true, c.Email == nil)
+	Expect(t, false, c.Phone.Valid)
+	Expect(t, true, c.City.Valid)
+	Expect(t, true, c.Year > 0)
+	Expect(t, true, c.Car != nil)
+	Expect(t, true, len(c.Address.City) > 0)
+	Expect(t, "", c.Skip)
+	Expect(t, true, len(c.Nested.Title) > 0)
+}
+
+func TestFillErrors(t *testing.T) {
+	var bad struct {
+		Name string `fake:"no.such.generator"`
+	}
+	NotExpect(t, nil, fakery.New().Fill(&bad))
+
+	var notNullable struct {
+		Name string `fake:"person.name,null=1"`
+	}
+	NotExpect(t, nil, fakery.New().Fill(&notNullable))
+
+	// The type is checked whatever the outcome of the draw
+	var rarelyNull struct {
+		Name string `fake:"person.name,null=0.01"`
+	}
+	for seed := int64(0); seed < 20; seed++ {
+		NotExpect(t, nil, fakery.NewFromSeed(seed).Fill(&rarelyNull))
+	}
+
+	NotExpect(t, nil, fakery.New().Fill(bad))
+}
+
+func TestGenerators(t *testing.T) {
+	f := fakery.New()
+	for _, name := range fakery.Generators() {
+		v, ok := f.Generate(name)
+		Expect(t, true, ok, name)
+		Expect(t, true, v != nil, name)
+	}
+}
@@ -0,0 +1,37 @@
+package tests
+
+import (
+	"fakery"
+	"strings"
+	"testing"
+)
+
+func TestExpand(t *testing.T) {
+	s, err := fakery.NewFromSeed(1).Expand("{{person.name}} <{{ internet.email }}>")
+	Expect(t, nil, err)
+	Expect(t, false, strings.Contains(s, "{{"))
+	Expect(t, true, strings.Contains(s, "@"))
+
+	_, err = fakery.New().Expand("{{no.such}}")
+	NotExpect(t, nil, err)
+}
+
+func TestLookupGenerator(t *testing.T) {
+	_, ok := fakery.LookupGenerator("email")
+	Expect(t, true, ok)
+	// "name" matches several generators
+	_, ok = fakery.LookupGenerator("name")
+	Expect(t, false, ok)
+}
+
+func TestBirthdateGenerator(t *testing.T) {
+	gen, _ := fakery.LookupGenerator("person.birthdate")
+	f := fakery.NewFromSeed(44)
+
+	// Aged as of the same fixed date as persons, not today
+	for i := 0; i < 200; i++ {
+		date := gen(f).(string)
+		Expect(t, true, date <= "2007-01-01", date)
+		Expect(t, true, date >= "1933-01-01", date)
+	}
+}
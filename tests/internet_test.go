@@ -2,7 +2,9 @@ package tests
 
 import (
 	"fakery"
+	"net"
 	"net/mail"
+	"net/url"
 	"testing"
 )
 
@@ -42,3 +44,26 @@ func TestFreeEmailDomain(t *testing.T) {
 func TestUserName(t *testing.T) {
 	Expect(t, true, len(fakery.New().UserName()) > 0)
 }
+
+func TestUUID(t *testing.T) {
+	u := fakery.NewFromSeed(1).UUID()
+	Expect(t, 36, len(u))
+	Expect(t, "4", u[14:15])
+	Expect(t, u, fakery.NewFromSeed(1).UUID())
+}
+
+func TestIPv4(t *testing.T) {
+	ip := net.ParseIP(fakery.New().IPv4())
+	Expect(t, true, ip != nil && ip.To4() != nil)
+}
+
+func TestIPv6(t *testing.T) {
+	Expect(t, true, net.ParseIP(fakery.New().IPv6()) != nil)
+}
+
+func TestURL(t *testing.T) {
+	u, err := url.Parse(fakery.New().URL())
+	Expect(t, nil, err)
+	Expect(t, "https", u.Scheme)
+	Expect(t, true, len(u.Host) > 0)
+}
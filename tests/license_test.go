@@ -0,0 +1,97 @@
+package tests
+
+import (
+	"fakery"
+	"strings"
+	"testing"
+)
+
+func TestDriversLicense(t *testing.T) {
+	f := fakery.NewFromSeed(48)
+
+	for i := 0; i < 50; i++ {
+		d := f.DriversLicense()
+		Expect(t, true, strings.HasPrefix(d.Jurisdiction, "US-"), d.Jurisdiction)
+		Expect(t, true, f.ValidateDriversLicense(d.Jurisdiction, d.Number), d.Jurisdiction, d.Number)
+		Expect(t, "C", d.Classes[0])
+		NotExpect(t, "", d.Address)
+		Expect(t, true, d.ExpiryDate > d.IssueDate)
+		// Expires on the birthday
+		Expect(t, d.Birthdate[4:], d.ExpiryDate[4:])
+	}
+
+	// The state follows the address
+	p := f.PersonWith(fakery.WithLastName("Robert"))
+	d := f.DriversLicenseFor(p, f.AddressWith(fakery.WithState("Florida")))
+	Expect(t, "US-FL", d.Jurisdiction)
+	Expect(t, "R163", d.Number[:4])
+	Expect(t, 13, len(d.Number))
+	Expect(t, p.FirstName, d.FirstName)
+	Expect(t, p.Birthdate, d.Birthdate)
+
+	d = f.DriversLicenseFor(f.PersonWith(fakery.WithLastName("Ashcraft")), &fakery.Address{State: "IL"})
+	Expect(t, "A261", d.Number[:4])
+
+	Expect(t, true, f.ValidateDriversLicense("US-TX", "12345678"))
+	Expect(t, false, f.ValidateDriversLicense("US-TX", "1234567"))
+	Expect(t, true, f.ValidateDriversLicense("US-CA", "A1234567"))
+	Expect(t, false, f.ValidateDriversLicense("US-CA", "12345678"))
+	Expect(t, false, f.ValidateDriversLicense("US-XX", "12345678"))
+}
+
+func TestDriversLicenseUK(t *testing.T) {
+	f := fakery.NewFromLocale("en_GB")
+
+	p := &fakery.Person{FirstName: "John", LastName: "MacDonald", Gender: "Male", Birthdate: "1976-07-03"}
+	d := f.DriversLicenseFor(p, nil)
+	Expect(t, "GB", d.Jurisdiction)
+	Expect(t, 16, len(d.Number))
+	Expect(t, "MCDON707036J99", d.Number[:14])
+	Expect(t, true, f.ValidateDriversLicense("GB", d.Number))
+	Expect(t, "B", d.Classes[1])
+
+	// Women have 50 added to the month
+	p = &fakery.Person{FirstName: "Ann", LastName: "Fox", Gender: "Female", Birthdate: "1985-11-21"}
+	d = f.DriversLicenseFor(p, nil)
+	Expect(t, "FOX99861215A99", d.Number[:14])
+
+	for i := 0; i < 20; i++ {
+		d = f.DriversLicense()
+		Expect(t, true, f.ValidateDriversLicense(d.Jurisdiction, d.Number), d.Number)
+	}
+	Expect(t, false, f.ValidateDriversLicense("GB", "MCDON713036J99AB"))
+}
+
+func TestDriversLicenseIndiaGermany(t *testing.T) {
+	for _, locale := range []string{"en_IN", "de_DE"} {
+		f := fakery.NewFromLocale(locale)
+		for i := 0; i < 20; i++ {
+			p := &fakery.Person{FirstName: "Asha", LastName: "Rao", Gender: "Female", Birthdate: "1990-01-01"}
+			d := f.DriversLicenseFor(p, nil)
+			Expect(t, locale[3:], d.Jurisdiction)
+			Expect(t, true, f.ValidateDriversLicense(d.Jurisdiction, d.Number), d.Number)
+		}
+	}
+
+	// Any jurisdiction can be chosen whatever the locale
+	f := fakery.NewFromSeed(48)
+	p := &fakery.Person{FirstName: "Asha", LastName: "Rao", Gender: "Female", Birthdate: "1990-01-01"}
+	for _, jurisdiction := range []string{"IN", "DE", "gb", "US-TX"} {
+		d, err := f.DriversLicenseIn(jurisdiction, p)
+		Expect(t, nil, err)
+		Expect(t, strings.ToUpper(jurisdiction), d.Jurisdiction)
+		Expect(t, true, f.ValidateDriversLicense(d.Jurisdiction, d.Number), d.Number)
+	}
+	d, err := f.DriversLicenseIn("US", p)
+	Expect(t, nil, err)
+	Expect(t, true, strings.HasPrefix(d.Jurisdiction, "US-"), d.Jurisdiction)
+	for _, jurisdiction := range []string{"FR", "US-XX", "TX", ""} {
+		_, err = f.DriversLicenseIn(jurisdiction, p)
+		NotExpect(t, nil, err, jurisdiction)
+	}
+
+	Expect(t, true, f.ValidateDriversLicense("IN", "MH14 20110062821"))
+	Expect(t, false, f.ValidateDriversLicense("IN", "XX14 20110062821"))
+	Expect(t, true, f.ValidateDriversLicense("DE", "B072RRE2I55"))
+	Expect(t, false, f.ValidateDriversLicense("DE", "B072RRE2I45"))
+}
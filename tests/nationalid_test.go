@@ -0,0 +1,97 @@
+package tests
+
+import (
+	"fakery"
+	"strings"
+	"testing"
+)
+
+func TestNationalID(t *testing.T) {
+	f := fakery.NewFromSeed(46)
+
+	for _, idType := range fakery.NationalIDTypes {
+		for i := 0; i < 50; i++ {
+			id := f.NationalID(idType)
+			Expect(t, true, f.ValidateNationalID(idType, id), idType, id)
+		}
+	}
+
+	Expect(t, "", f.NationalID("unknown"))
+	Expect(t, false, f.ValidateNationalID("unknown", "123"))
+}
+
+func TestValidateNationalID(t *testing.T) {
+	f := fakery.NewFromSeed(46)
+
+	valid := map[fakery.NationalIDType][]string{
+		fakery.IDSSN:           {"123-45-6789", "078051120"},
+		fakery.IDITIN:          {"912-70-1234"},
+		fakery.IDNINO:          {"AB123456C", "ab 12 34 56 c"},
+		fakery.IDAadhaar:       {"2341 2341 2346"},
+		fakery.IDPAN:           {"ABCPE1234F"},
+		fakery.IDCPF:           {"529.982.247-25"},
+		fakery.IDCNPJ:          {"11.222.333/0001-81"},
+		fakery.IDDNI:           {"12345678Z"},
+		fakery.IDNIE:           {"X1234567L"},
+		fakery.IDCodiceFiscale: {"RSSMRA85T10A562S"},
+		fakery.IDINSEE:         {"1 84 03 75 123 456 90"},
+		fakery.IDBSN:           {"111222333"},
+	}
+	invalid := map[fakery.NationalIDType][]string{
+		fakery.IDSSN:           {"666-45-6789", "000-45-6789", "912-45-6789", "123-00-6789"},
+		fakery.IDITIN:          {"912-45-1234", "123-70-1234"},
+		fakery.IDNINO:          {"GB123456C", "AB123456E", "DA123456C"},
+		fakery.IDAadhaar:       {"2341 2341 2345", "1341 2341 2346"},
+		fakery.IDPAN:           {"ABCPE1234", "ABCDE1234F"},
+		fakery.IDCPF:           {"529.982.247-26", "111.111.111-11"},
+		fakery.IDCNPJ:          {"11.222.333/0001-82"},
+		fakery.IDDNI:           {"12345678A", "1234567Z"},
+		fakery.IDNIE:           {"X1234567A", "A1234567L"},
+		fakery.IDCodiceFiscale: {"RSSMRA85T10A562T", "RSSMRA85Z10A562S"},
+		fakery.IDINSEE:         {"1 84 03 75 123 456 91", "3 84 03 75 123 456 90"},
+		fakery.IDBSN:           {"111222334", "000000000"},
+	}
+
+	for idType, ids := range valid {
+		for _, id := range ids {
+			Expect(t, true, f.ValidateNationalID(idType, id), idType, id)
+		}
+	}
+	for idType, ids := range invalid {
+		for _, id := range ids {
+			Expect(t, false, f.ValidateNationalID(idType, id), idType, id)
+		}
+	}
+}
+
+func TestNationalIDFor(t *testing.T) {
+	f := fakery.NewFromSeed(46)
+
+	p := f.PersonWith(fakery.WithFirstName("Mario"), fakery.WithLastName("Rossi"), fakery.WithGender(fakery.GenderMale))
+	p.Birthdate = "1985-12-10"
+
+	cf := f.NationalIDFor(p, fakery.IDCodiceFiscale)
+	Expect(t, "RSSMRA85T10", cf[:11])
+	Expect(t, true, f.ValidateNationalID(fakery.IDCodiceFiscale, cf), cf)
+
+	// Women have 40 added to the day of birth
+	p.Gender = "Female"
+	Expect(t, "RSSMRA85T50", f.NationalIDFor(p, fakery.IDCodiceFiscale)[:11])
+
+	insee := f.NationalIDFor(p, fakery.IDINSEE)
+	Expect(t, true, strings.HasPrefix(insee, "2 85 12 "), insee)
+
+	pan := f.NationalIDFor(p, fakery.IDPAN)
+	Expect(t, byte('P'), pan[3])
+	Expect(t, byte('R'), pan[4])
+
+	// Persons carry the number of their locale
+	for i := 0; i < 20; i++ {
+		person := f.Person()
+		Expect(t, true, f.ValidateNationalID(fakery.IDSSN, person.NationalID), person.NationalID)
+	}
+
+	gen, ok := fakery.LookupGenerator("national_id.cpf")
+	Expect(t, true, ok)
+	Expect(t, true, f.ValidateNationalID(fakery.IDCPF, gen(f).(string)))
+}
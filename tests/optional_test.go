@@ -0,0 +1,24 @@
+package tests
+
+import (
+	"fakery"
+	"testing"
+)
+
+func TestMaybe(t *testing.T) {
+	f := fakery.New()
+	Expect(t, true, f.Maybe(1.0, f.Email) == nil)
+	Expect(t, true, f.Maybe(0.0, f.Email) != nil)
+}
+
+func TestMaybeNullString(t *testing.T) {
+	f := fakery.New()
+	Expect(t, false, f.MaybeNullString(1.0, f.Email).Valid)
+	Expect(t, true, f.MaybeNullString(0.0, f.Email).Valid)
+}
+
+func TestNullable(t *testing.T) {
+	f := fakery.New()
+	Expect(t, true, fakery.Nullable(f, 1.0, f.BookYear) == nil)
+	Expect(t, true, *fakery.Nullable(f, 0.0, f.BookYear) > 0)
+}
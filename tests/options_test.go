@@ -0,0 +1,94 @@
+package tests
+
+import (
+	"fakery"
+	"strings"
+	"testing"
+)
+
+func TestPersonWithOptions(t *testing.T) {
+	f := fakery.NewFromSeed(45)
+
+	for i := 0; i < 20; i++ {
+		p := f.PersonWith(fakery.WithNameFormat("{{prefix}} {{firstName}} {{lastName}}"), fakery.WithEmailDomain("corp.test"))
+		NotExpect(t, "", p.Prefix)
+		Expect(t, "", p.Suffix)
+		Expect(t, p.Prefix+" "+p.Name, p.FullName)
+		Expect(t, true, strings.HasSuffix(p.Email, "@corp.test"), p.Email)
+
+		// No prefixes or suffixes
+		p = f.PersonWith(fakery.WithNameFormat("{{lastName}}, {{firstName}}"))
+		Expect(t, "", p.Prefix)
+		Expect(t, p.LastName+", "+p.FirstName, p.FullName)
+
+		// Only the names, as they always were
+		p = f.PersonMale()
+		Expect(t, "Male", p.Gender)
+		NotExpect(t, "", p.FirstName)
+		Expect(t, "", p.Email)
+		Expect(t, "", p.Birthdate)
+		Expect(t, "Female", f.PersonFemale().Gender)
+	}
+}
+
+func TestAddressWithOptions(t *testing.T) {
+	f := fakery.NewFromSeed(45)
+
+	for i := 0; i < 10; i++ {
+		a := f.AddressWith(fakery.WithCountry("Canada"), fakery.WithBuilding(false))
+		Expect(t, "Canada", a.Country)
+		Expect(t, "", a.Building)
+		Expect(t, true, strings.HasSuffix(a.FullAddress, "Canada"))
+
+		NotExpect(t, "", f.AddressWith(fakery.WithBuilding(true)).Building)
+	}
+}
+
+func TestCarWithOptions(t *testing.T) {
+	f := fakery.NewFromSeed(45)
+
+	for i := 0; i < 20; i++ {
+		c := f.CarWith(fakery.WithMake("Tesla"), fakery.WithFuelType("Electric"), fakery.WithYearRange(2021, 2024))
+		Expect(t, "Tesla", c.Make)
+		Expect(t, "Electric", c.Type)
+		Expect(t, true, c.Year >= 2021 && c.Year <= 2024, c.Year)
+
+		// Reversed bounds are swapped
+		c = f.CarWith(fakery.WithYearRange(2024, 2021))
+		Expect(t, true, c.Year >= 2021 && c.Year <= 2024, c.Year)
+	}
+}
+
+func TestBookWithOptions(t *testing.T) {
+	f := fakery.NewFromSeed(45)
+
+	for i := 0; i < 20; i++ {
+		b := f.BookWith(fakery.WithGenre("Fantasy"), fakery.WithAuthor("Jane Doe"),
+			fakery.WithBookFormat("Hardcover"), fakery.WithPublicationYears(2000, 2009))
+		Expect(t, "Fantasy", b.Genre)
+		Expect(t, "Jane Doe", b.Author)
+		Expect(t, "Hardcover", b.Format)
+		Expect(t, true, b.Year >= 2000 && b.Year <= 2009, b.Year)
+
+		b = f.BookWith(fakery.WithPublicationYears(2009, 2000))
+		Expect(t, true, b.Year >= 2000 && b.Year <= 2009, b.Year)
+	}
+}
+
+func TestCreditCardWithOptions(t *testing.T) {
+	f := fakery.NewFromSeed(45)
+
+	for i := 0; i < 20; i++ {
+		c := f.CreditCardWith(fakery.WithCardType("AMEX"), fakery.WithCardHolder("Jane Doe"))
+		Expect(t, "AMEX", c.Type)
+		Expect(t, "Jane Doe", c.Name)
+		Expect(t, 15, len(c.Number))
+		Expect(t, 4, len(c.CVV))
+
+		// Never an error message as the number
+		c = f.CreditCardWith(fakery.WithCardType("Bogus"))
+		NotExpect(t, "Bogus", c.Type)
+		Expect(t, true, c.Validate(), c.Number)
+		Expect(t, c.Type, fakery.CardNetworkFromNumber(c.Number))
+	}
+}
@@ -0,0 +1,82 @@
+package tests
+
+import (
+	"fakery"
+	"strings"
+	"testing"
+)
+
+func TestPassport(t *testing.T) {
+	f := fakery.NewFromSeed(47)
+
+	for i := 0; i < 20; i++ {
+		p := f.PersonWith(fakery.WithLastName("O'Neil-Smith"))
+		d := f.PassportFor(p)
+
+		Expect(t, fakery.DocPassport, d.Type)
+		Expect(t, "USA", d.IssuingCountry)
+		Expect(t, "USA", d.Nationality)
+		Expect(t, 9, len(d.Number))
+		Expect(t, 2, len(d.MRZ))
+		Expect(t, 44, len(d.MRZ[0]))
+		Expect(t, 44, len(d.MRZ[1]))
+		Expect(t, true, strings.HasPrefix(d.MRZ[0], "P<USAONEIL<SMITH<<"+strings.ToUpper(p.FirstName)), d.MRZ[0])
+		Expect(t, true, f.ValidateMRZ(d.MRZ), d.MRZ)
+
+		// Sex and birthdate agree with the person
+		Expect(t, p.Gender[:1], d.MRZ[1][20:21])
+		Expect(t, p.DateOfBirth().Format("060102"), d.MRZ[1][13:19])
+		Expect(t, true, d.ExpiryDate > d.IssueDate)
+	}
+}
+
+func TestIDCardAndVisa(t *testing.T) {
+	f := fakery.NewFromSeed(47)
+
+	for i := 0; i < 20; i++ {
+		d := f.IDCard()
+		Expect(t, 3, len(d.MRZ))
+		for _, line := range d.MRZ {
+			Expect(t, 30, len(line))
+		}
+		Expect(t, true, strings.HasPrefix(d.MRZ[0], "I<USA"+d.Number))
+		Expect(t, true, f.ValidateMRZ(d.MRZ), d.MRZ)
+
+		v := f.Visa()
+		NotExpect(t, v.Nationality, v.IssuingCountry)
+		Expect(t, "USA", v.Nationality)
+		Expect(t, true, strings.HasPrefix(v.MRZ[0], "V<"))
+		Expect(t, 44, len(v.MRZ[1]))
+		Expect(t, true, f.ValidateMRZ(v.MRZ), v.MRZ)
+	}
+}
+
+func TestValidateMRZ(t *testing.T) {
+	f := fakery.New()
+
+	// Specimen from ICAO 9303 part 4
+	td3 := []string{
+		"P<UTOERIKSSON<<ANNA<MARIA<<<<<<<<<<<<<<<<<<<",
+		"L898902C36UTO7408122F1204159ZE184226B<<<<<10",
+	}
+	Expect(t, true, f.ValidateMRZ(td3))
+
+	td3[1] = "L898902C36UTO7408122F1204158ZE184226B<<<<<10"
+	Expect(t, false, f.ValidateMRZ(td3))
+
+	// Specimen from ICAO 9303 part 5
+	td1 := []string{
+		"I<UTOD231458907<<<<<<<<<<<<<<<",
+		"7408122F1204159UTO<<<<<<<<<<<6",
+		"ERIKSSON<<ANNA<MARIA<<<<<<<<<<",
+	}
+	Expect(t, true, f.ValidateMRZ(td1))
+	Expect(t, false, f.ValidateMRZ(td1[:2]))
+
+	// Other locales issue their own documents
+	f.SetLocale("en_GB")
+	d := f.PassportFor(&fakery.Person{FirstName: "Zoë", LastName: "Brontë", Gender: "Female", Birthdate: "1990-05-17"})
+	Expect(t, "P<GBRBRONTE<<ZOE<", d.MRZ[0][:17])
+	Expect(t, "900517", d.MRZ[1][13:19])
+	Expect(t, true, f.ValidateMRZ(d.MRZ))
+}
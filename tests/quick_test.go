@@ -0,0 +1,37 @@
+package tests
+
+import (
+	"fakery"
+	"fakery/fakerytest"
+	"strings"
+	"testing"
+	"testing/quick"
+)
+
+func TestQuickPerson(t *testing.T) {
+	err := quick.Check(func(p fakery.Person) bool {
+		return strings.HasPrefix(p.Name, p.FirstName) && strings.Contains(p.Email, "@")
+	}, nil)
+	Expect(t, nil, err)
+}
+
+func TestQuickRecords(t *testing.T) {
+	err := quick.Check(func(a fakery.Address, c fakery.CreditCard, car fakery.Car, b fakery.Book, col fakery.Color) bool {
+		return len(a.City) > 0 && len(c.Number) > 0 && len(car.Make) > 0 && len(b.Title) > 0 && strings.HasPrefix(col.Hex, "#")
+	}, &quick.Config{MaxCount: 20})
+	Expect(t, nil, err)
+}
+
+func TestFromBytes(t *testing.T) {
+	Expect(t, fakerytest.FromBytes([]byte("x")).Name(), fakerytest.FromBytes([]byte("x")).Name())
+	NotExpect(t, fakerytest.FromBytes([]byte("x")).Seed(), fakerytest.FromBytes([]byte("y")).Seed())
+}
+
+func FuzzPerson(f *testing.F) {
+	fakerytest.Fuzz(f, func(t *testing.T, fk *fakery.Fakery) {
+		p := fk.Person()
+		if !strings.HasPrefix(p.FullName, p.Prefix) || !strings.Contains(p.FullName, p.LastName) {
+			t.Errorf("inconsistent person %+v", p)
+		}
+	})
+}
@@ -0,0 +1,27 @@
+package tests
+
+import (
+	"fakery"
+	"testing"
+)
+
+func TestPCGSource(t *testing.T) {
+	f := fakery.NewWithSource(fakery.NewPCGSource(11))
+	g := fakery.NewWithSource(fakery.NewPCGSource(11))
+	Expect(t, f.Name(), g.Name())
+}
+
+func TestChaCha8Source(t *testing.T) {
+	f := fakery.NewWithSource(fakery.NewChaCha8Source(11))
+	g := fakery.NewWithSource(fakery.NewChaCha8Source(11))
+	Expect(t, f.Name(), g.Name())
+}
+
+func TestCryptoSource(t *testing.T) {
+	f := fakery.NewWithSource(fakery.NewCryptoSource())
+	Expect(t, true, len(f.Name()) > 0)
+}
+
+func TestUnseeded(t *testing.T) {
+	NotExpect(t, fakery.New().Seed(), fakery.New().Seed())
+}
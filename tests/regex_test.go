@@ -0,0 +1,30 @@
+package tests
+
+import (
+	"fakery"
+	"regexp"
+	"testing"
+)
+
+func TestRegexify(t *testing.T) {
+	patterns := []string{
+		`^[A-Z]{2}-\d{4}$`,
+		`(foo|bar)+baz?`,
+		`[^a-z]{3,}`,
+		`\w+@\w+\.com`,
+		`(?i)abc`,
+		`.{5}`,
+	}
+
+	f := fakery.New()
+	for _, p := range patterns {
+		for i := 0; i < 10; i++ {
+			s, err := f.Regexify(p)
+			Expect(t, nil, err)
+			Expect(t, true, regexp.MustCompile(p).MatchString(s), p, s)
+		}
+	}
+
+	_, err := f.Regexify(`(unclosed`)
+	NotExpect(t, nil, err)
+}
@@ -0,0 +1,75 @@
+package tests
+
+import (
+	"fakery"
+	"slices"
+	"testing"
+)
+
+var sampleItems = []string{"a", "b", "c", "d", "e"}
+
+func TestPick(t *testing.T) {
+	Expect(t, true, slices.Contains(sampleItems, fakery.Pick(fakery.New(), sampleItems)))
+}
+
+func TestPickExcept(t *testing.T) {
+	f := fakery.New()
+	for i := 0; i < 20; i++ {
+		NotExpect(t, "a", fakery.PickExcept(f, sampleItems, "a", "b"))
+		NotExpect(t, "b", fakery.PickExcept(f, sampleItems, "a", "b"))
+	}
+	Expect(t, "", fakery.PickExcept(f, []string{"a"}, "a"))
+}
+
+func TestRandomStringExcl(t *testing.T) {
+	xs := slices.Clone(sampleItems)
+	f := fakery.New()
+	for i := 0; i < 20; i++ {
+		NotExpect(t, "c", f.RandomStringExcl(xs, "c"))
+	}
+	// Input must not be modified, even if the item is missing
+	f.RandomStringExcl(xs, "z")
+	Expect(t, true, slices.Equal(sampleItems, xs))
+}
+
+func TestSample(t *testing.T) {
+	xs := slices.Clone(sampleItems)
+	s := fakery.Sample(fakery.New(), xs, 3)
+	Expect(t, 3, len(s))
+	Expect(t, true, s[0] != s[1] && s[1] != s[2] && s[0] != s[2])
+	Expect(t, true, slices.Equal(sampleItems, xs))
+	Expect(t, 5, len(fakery.Sample(fakery.New(), xs, 10)))
+	Expect(t, 0, len(fakery.Sample(fakery.New(), xs, -1)))
+}
+
+func TestShuffle(t *testing.T) {
+	s := fakery.Shuffle(fakery.New(), sampleItems)
+	slices.Sort(s)
+	Expect(t, true, slices.Equal(sampleItems, s))
+}
+
+func TestPermutation(t *testing.T) {
+	p := fakery.New().Permutation(10)
+	slices.Sort(p)
+	for i := range p {
+		Expect(t, i, p[i])
+	}
+}
+
+func TestWeightedPick(t *testing.T) {
+	f := fakery.New()
+	for i := 0; i < 20; i++ {
+		v, err := fakery.WeightedPick(f, []string{"a", "b"}, []float64{0, 3})
+		Expect(t, nil, err)
+		Expect(t, "b", v)
+	}
+
+	_, err := fakery.WeightedPick(f, []string{"a", "b"}, []float64{1})
+	NotExpect(t, nil, err)
+	_, err = fakery.WeightedPick(f, []string{"a", "b"}, []float64{0, 0})
+	NotExpect(t, nil, err)
+}
+
+func TestOneOf(t *testing.T) {
+	Expect(t, true, slices.Contains(sampleItems, fakery.New().OneOf(sampleItems)))
+}
@@ -0,0 +1,229 @@
+package tests
+
+import (
+	"fakery"
+	"fakery/schema"
+	"net/mail"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+var userSchema = `{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "type": "object",
+  "required": ["id", "firstName", "email", "age", "tags", "status", "address", "code"],
+  "properties": {
+    "id": {"type": "string", "format": "uuid"},
+    "firstName": {"type": "string"},
+    "email": {"type": "string", "format": "email"},
+    "age": {"type": "integer", "minimum": 18, "exclusiveMaximum": 30},
+    "score": {"type": "number", "minimum": 0, "maximum": 1},
+    "tags": {"type": "array", "items": {"enum": ["a", "b", "c"]}, "minItems": 2, "maxItems": 3, "uniqueItems": true},
+    "status": {"const": "active"},
+    "code": {"type": "string", "pattern": "^[A-Z]{3}-[0-9]{2}$"},
+    "nick": {"type": "string", "minLength": 20, "maxLength": 25},
+    "address": {"$ref": "#/$defs/address"},
+    "contact": {"oneOf": [{"type": "string", "format": "ipv4"}, {"type": "integer"}]}
+  },
+  "$defs": {
+    "address": {
+      "type": "object",
+      "required": ["city"],
+      "properties": {"city": {"type": "string"}, "zip": {"type": "string", "maxLength": 5}}
+    }
+  }
+}`
+
+func TestSchemaGenerate(t *testing.T) {
+	f := fakery.New()
+	for i := 0; i < 20; i++ {
+		v, err := schema.Generate(f, []byte(userSchema))
+		Expect(t, nil, err)
+
+		doc := v.(map[string]interface{})
+		Expect(t, 36, len(doc["id"].(string)))
+		_, err = mail.ParseAddress(doc["email"].(string))
+		Expect(t, nil, err)
+
+		age := doc["age"].(int)
+		Expect(t, true, age >= 18 && age < 30)
+
+		tags := doc["tags"].([]interface{})
+		Expect(t, true, len(tags) >= 2 && len(tags) <= 3)
+		Expect(t, "active", doc["status"])
+		Expect(t, true, regexp.MustCompile(`^[A-Z]{3}-[0-9]{2}$`).MatchString(doc["code"].(string)))
+
+		if nick, ok := doc["nick"].(string); ok {
+			Expect(t, true, len(nick) >= 20 && len(nick) <= 25, nick)
+		}
+		if score, ok := doc["score"].(float64); ok {
+			Expect(t, true, score >= 0 && score <= 1)
+		}
+
+		address := doc["address"].(map[string]interface{})
+		Expect(t, true, len(address["city"].(string)) > 0)
+		if zip, ok := address["zip"].(string); ok {
+			Expect(t, true, len(zip) <= 5)
+		}
+	}
+}
+
+var petstore = `
+openapi: 3.0.3
+components:
+  schemas:
+    Pet:
+      type: object
+      required: [name, owner, tags]
+      properties:
+        name: {type: string}
+        owner: {$ref: '#/components/schemas/Owner'}
+        tags: {type: array, items: {type: string}}
+        parent: {$ref: '#/components/schemas/Pet'}
+    Owner:
+      allOf:
+        - $ref: '#/components/schemas/Named'
+        - type: object
+          required: [city]
+          properties:
+            city: {type: string}
+    Named:
+      type: object
+      required: [lastName]
+      properties:
+        lastName: {type: string}
+`
+
+func TestSchemaOpenAPI(t *testing.T) {
+	g, err := schema.New(fakery.New(), []byte(petstore))
+	Expect(t, nil, err)
+
+	v, err := g.GenerateRef("#/components/schemas/Pet")
+	Expect(t, nil, err)
+
+	pet := v.(map[string]interface{})
+	owner := pet["owner"].(map[string]interface{})
+	Expect(t, true, len(owner["lastName"].(string)) > 0)
+	Expect(t, true, len(owner["city"].(string)) > 0)
+
+	_, err = g.GenerateRef("#/components/schemas/Missing")
+	NotExpect(t, nil, err)
+}
+
+func TestSchemaNames(t *testing.T) {
+	v, err := schema.Generate(fakery.New(), []byte(`{"properties": {"isbn": {"type": "string"}}, "required": ["isbn"]}`))
+	Expect(t, nil, err)
+	isbn := v.(map[string]interface{})["isbn"].(string)
+	Expect(t, true, fakery.New().ValidateISBN(isbn), isbn)
+	Expect(t, true, strings.Contains(isbn, "-"))
+}
+
+func TestSchemaNumberBounds(t *testing.T) {
+	f := fakery.New()
+
+	for i := 0; i < 50; i++ {
+		v, err := schema.Generate(f, []byte(`{"type": "number", "minimum": 1, "maximum": 10, "multipleOf": 3}`))
+		Expect(t, nil, err)
+		n := v.(float64)
+		Expect(t, true, n == 3 || n == 6 || n == 9, n)
+
+		v, err = schema.Generate(f, []byte(`{"type": "number", "exclusiveMinimum": 3, "exclusiveMaximum": 9, "multipleOf": 3}`))
+		Expect(t, nil, err)
+		Expect(t, 6.0, v)
+	}
+
+	for _, s := range []string{
+		`{"type": "number", "minimum": 1, "maximum": 2, "multipleOf": 3}`,
+		`{"type": "number", "exclusiveMinimum": 3, "exclusiveMaximum": 6, "multipleOf": 3}`,
+		`{"type": "integer", "minimum": 1, "maximum": 2, "multipleOf": 3}`,
+		`{"type": "integer", "minimum": 5, "maximum": 4}`,
+		`{"type": "integer", "minimum": 5, "maximum": 5, "exclusiveMaximum": true}`,
+	} {
+		_, err := schema.Generate(f, []byte(s))
+		NotExpect(t, nil, err, s)
+	}
+}
+
+func TestSchemaDates(t *testing.T) {
+	s := []byte(`{"type": "string", "format": "date-time"}`)
+	a, _ := schema.Generate(fakery.NewFromSeed(35), s)
+	b, _ := schema.Generate(fakery.NewFromSeed(35), s)
+	Expect(t, a, b)
+}
+
+func TestSchemaOpenBounds(t *testing.T) {
+	f := fakery.New()
+
+	for i := 0; i < 50; i++ {
+		v, err := schema.Generate(f, []byte(`{"type": "integer", "maximum": -5}`))
+		Expect(t, nil, err)
+		Expect(t, true, v.(int) <= -5, v)
+
+		v, err = schema.Generate(f, []byte(`{"type": "number", "exclusiveMaximum": -5}`))
+		Expect(t, nil, err)
+		Expect(t, true, v.(float64) < -5, v)
+
+		v, err = schema.Generate(f, []byte(`{"type": "integer", "minimum": 2000}`))
+		Expect(t, nil, err)
+		Expect(t, true, v.(int) >= 2000, v)
+
+		// OpenAPI 3.0 boolean exclusive bounds
+		v, err = schema.Generate(f, []byte(`{"type": "integer", "minimum": 1, "maximum": 3, "exclusiveMinimum": true, "exclusiveMaximum": true}`))
+		Expect(t, nil, err)
+		Expect(t, 2, v)
+	}
+}
+
+func TestSchemaFormatLengths(t *testing.T) {
+	f := fakery.New()
+
+	for i := 0; i < 20; i++ {
+		v, err := schema.Generate(f, []byte(`{"type": "string", "format": "email", "maxLength": 30}`))
+		Expect(t, nil, err)
+		_, err = mail.ParseAddress(v.(string))
+		Expect(t, nil, err, v)
+		Expect(t, true, len(v.(string)) <= 30, v)
+	}
+
+	for _, s := range []string{
+		`{"type": "string", "format": "email", "maxLength": 5}`,
+		`{"type": "string", "format": "uuid", "minLength": 40}`,
+		`{"type": "string", "format": "date", "maxLength": 8}`,
+	} {
+		_, err := schema.Generate(f, []byte(s))
+		NotExpect(t, nil, err, s)
+	}
+}
+
+func TestSchemaRecursion(t *testing.T) {
+	// Required recursion ends on the null branch
+	v, err := schema.Generate(fakery.New(), []byte(`{"type": ["object", "null"], "required": ["next"], "properties": {"next": {"$ref": "#"}}}`))
+	Expect(t, nil, err)
+	depth := 0
+	for v != nil {
+		v = v.(map[string]interface{})["next"]
+		depth++
+	}
+	Expect(t, true, depth > 1 && depth <= schema.DefaultMaxDepth, depth)
+
+	// Or fails without one
+	for _, s := range []string{
+		`{"type": "object", "required": ["next"], "properties": {"next": {"$ref": "#"}}}`,
+		`{"type": "array", "minItems": 1, "items": {"$ref": "#"}}`,
+		`{"oneOf": [{"$ref": "#"}]}`,
+	} {
+		_, err = schema.Generate(fakery.New(), []byte(s))
+		NotExpect(t, nil, err, s)
+	}
+}
+
+func TestSchemaBadAllOf(t *testing.T) {
+	for _, s := range []string{
+		`{"allOf": [{"properties": []}]}`,
+		`{"allOf": [{"required": "id"}]}`,
+	} {
+		_, err := schema.Generate(fakery.New(), []byte(s))
+		NotExpect(t, nil, err, s)
+	}
+}
@@ -0,0 +1,81 @@
+package tests
+
+import (
+	"encoding/json"
+	"fakery/server"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+type serverPage struct {
+	Seed  int64                    `json:"seed"`
+	Page  int                      `json:"page"`
+	Count int                      `json:"count"`
+	Data  []map[string]interface{} `json:"data"`
+	Next  string                   `json:"next"`
+}
+
+func get(t *testing.T, h http.Handler, method, target, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(method, target, strings.NewReader(body)))
+	return rec
+}
+
+func TestServerGenerate(t *testing.T) {
+	h := server.New(server.Config{})
+
+	rec := get(t, h, "GET", "/v1/person?count=3&seed=7", "")
+	Expect(t, http.StatusOK, rec.Code)
+	Expect(t, "*", rec.Header().Get("Access-Control-Allow-Origin"))
+
+	var p serverPage
+	Expect(t, nil, json.Unmarshal(rec.Body.Bytes(), &p))
+	Expect(t, 3, len(p.Data))
+	Expect(t, int64(7), p.Seed)
+
+	// Page 2 is stable no matter what was requested before
+	rec2 := get(t, h, "GET", p.Next, "")
+	rec3 := get(t, h, "GET", "/v1/person?count=3&seed=7&page=2", "")
+	Expect(t, rec2.Body.String(), rec3.Body.String())
+	NotExpect(t, rec.Body.String(), rec2.Body.String())
+
+	Expect(t, http.StatusOK, get(t, h, "GET", "/v1/user_agent", "").Code)
+	Expect(t, http.StatusOK, get(t, h, "GET", "/v1", "").Code)
+	Expect(t, http.StatusNotFound, get(t, h, "GET", "/v1/nope", "").Code)
+	Expect(t, http.StatusBadRequest, get(t, h, "GET", "/v1/person?count=0", "").Code)
+	Expect(t, http.StatusNoContent, get(t, h, "OPTIONS", "/v1/person", "").Code)
+}
+
+func TestServerSchema(t *testing.T) {
+	h := server.New(server.Config{CORSOrigin: "http://localhost:3000"})
+
+	rec := get(t, h, "POST", "/v1/schema?count=2", `{"type": "object", "required": ["email"], "properties": {"email": {"type": "string", "format": "email"}}}`)
+	Expect(t, http.StatusOK, rec.Code)
+	Expect(t, "http://localhost:3000", rec.Header().Get("Access-Control-Allow-Origin"))
+
+	var p serverPage
+	Expect(t, nil, json.Unmarshal(rec.Body.Bytes(), &p))
+	Expect(t, 2, len(p.Data))
+	Expect(t, true, strings.Contains(p.Data[0]["email"].(string), "@"))
+
+	Expect(t, http.StatusBadRequest, get(t, h, "POST", "/v1/schema", "[1, 2").Code)
+
+	// Endlessly recursive schemas are rejected, not a crash
+	recursive := `{"type": "object", "required": ["next"], "properties": {"next": {"$ref": "#"}}}`
+	Expect(t, http.StatusUnprocessableEntity, get(t, h, "POST", "/v1/schema", recursive).Code)
+}
+
+func TestServerInjection(t *testing.T) {
+	h := server.New(server.Config{ErrorRate: 1})
+	Expect(t, true, get(t, h, "GET", "/v1/car", "").Code >= 500)
+	Expect(t, http.StatusOK, get(t, h, "GET", "/v1/car?error_rate=0", "").Code)
+
+	start := time.Now()
+	get(t, server.New(server.Config{}), "GET", "/v1/car?latency=50ms", "")
+	Expect(t, true, time.Since(start) >= 50*time.Millisecond)
+	Expect(t, http.StatusBadRequest, get(t, h, "GET", "/v1/car?latency=soon", "").Code)
+}
@@ -0,0 +1,45 @@
+package tests
+
+import (
+	"fakery"
+	"testing"
+)
+
+func TestSnapshot(t *testing.T) {
+	f := fakery.NewFromSeed(7)
+	f.SetLocale("en_GB")
+	f.Person()
+
+	b, err := f.Snapshot()
+	Expect(t, nil, err)
+
+	g, err := fakery.Restore(b)
+	Expect(t, nil, err)
+	Expect(t, f.Seed(), g.Seed())
+
+	for i := 0; i < 5; i++ {
+		Expect(t, f.Name(), g.Name())
+	}
+}
+
+func TestRestoreInvalid(t *testing.T) {
+	_, err := fakery.Restore([]byte("not a snapshot"))
+	NotExpect(t, nil, err)
+}
+
+func TestSnapshotChaCha8(t *testing.T) {
+	f := fakery.NewWithSource(fakery.NewChaCha8Source(7))
+	f.Person()
+
+	b, err := f.Snapshot()
+	Expect(t, nil, err)
+
+	g, err := fakery.Restore(b)
+	Expect(t, nil, err)
+	Expect(t, f.Email(), g.Email())
+}
+
+func TestSnapshotCrypto(t *testing.T) {
+	_, err := fakery.NewWithSource(fakery.NewCryptoSource()).Snapshot()
+	NotExpect(t, nil, err)
+}
@@ -0,0 +1,160 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fakery"
+	"fakery/synth"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"testing"
+)
+
+// Write a sample CSV of n customers
+func sampleCSV(f *fakery.Fakery, n int) []byte {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+
+	w.Write([]string{"id", "name", "email", "country", "status", "amount", "signup", "code", "note"})
+	for i := 0; i < n; i++ {
+		status, _ := fakery.WeightedPick(f, []string{"active", "blocked"}, []float64{0.75, 0.25})
+		note := ""
+		if f.Chance(0.5) {
+			note = f.AdjectivePositive()
+		}
+		w.Write([]string{
+			strconv.Itoa(i + 1),
+			f.Name(),
+			f.Email(),
+			f.Country(),
+			status,
+			fmt.Sprintf("%.2f", 50+10*f.NormFloat64()),
+			fmt.Sprintf("2024-%02d-%02d", f.RandIntBetween(1, 13), f.RandIntBetween(1, 29)),
+			f.Numerify(f.Alphify("@@-###")),
+			note,
+		})
+	}
+	w.Flush()
+
+	return buf.Bytes()
+}
+
+func TestSynthProfile(t *testing.T) {
+	sample := sampleCSV(fakery.NewFromSeed(43), 300)
+
+	p, err := synth.ProfileCSV(bytes.NewReader(sample))
+	Expect(t, nil, err)
+	Expect(t, 300, p.Rows)
+
+	cols := make(map[string]*synth.Column)
+	for _, c := range p.Columns {
+		cols[c.Name] = c
+	}
+
+	Expect(t, synth.Int, cols["id"].Type)
+	Expect(t, 1.0, cols["id"].Min)
+	Expect(t, 300.0, cols["id"].Max)
+	Expect(t, "person.name", cols["name"].Kind)
+	Expect(t, "internet.email", cols["email"].Kind)
+	Expect(t, "address.country", cols["country"].Kind)
+	Expect(t, 2, len(cols["status"].Categories))
+	Expect(t, "active", cols["status"].Categories[0].Value)
+	Expect(t, synth.Float, cols["amount"].Type)
+	Expect(t, 2, cols["amount"].Decimals)
+	Expect(t, true, math.Abs(cols["amount"].Mean-50) < 2)
+	Expect(t, synth.Date, cols["signup"].Type)
+	Expect(t, "2006-01-02", cols["signup"].Layout)
+	Expect(t, "[A-Z]{2}-[0-9]{3}", cols["code"].Shapes[0].Value)
+	Expect(t, 1.0, cols["code"].Shapes[0].Freq)
+	Expect(t, true, math.Abs(cols["note"].NullRate-0.5) < 0.1)
+
+	_, err = synth.ProfileCSV(bytes.NewReader(nil))
+	NotExpect(t, nil, err)
+}
+
+func TestSynthGenerate(t *testing.T) {
+	sample := sampleCSV(fakery.NewFromSeed(43), 300)
+	p, _ := synth.ProfileCSV(bytes.NewReader(sample))
+
+	real := make(map[string]bool)
+	records, _ := csv.NewReader(bytes.NewReader(sample)).ReadAll()
+	for _, row := range records[1:] {
+		real[row[1]] = true
+		real[row[2]] = true
+		real[row[7]] = true
+	}
+
+	var out bytes.Buffer
+	err := p.WriteCSV(&out, fakery.NewFromSeed(1), 2000)
+	Expect(t, nil, err)
+
+	rows, err := csv.NewReader(&out).ReadAll()
+	Expect(t, nil, err)
+	Expect(t, 2001, len(rows))
+	Expect(t, "id", rows[0][0])
+
+	code := regexp.MustCompile(`^[A-Z]{2}-[0-9]{3}$`)
+	var active, nulls int
+	var sum float64
+	for _, row := range rows[1:] {
+		// Names, emails and codes are never copied
+		Expect(t, false, real[row[1]], row[1])
+		Expect(t, false, real[row[2]], row[2])
+		Expect(t, false, real[row[7]], row[7])
+		Expect(t, true, code.MatchString(row[7]), row[7])
+		// Within the lengths of the sample
+		for j, col := range p.Columns {
+			if n := len([]rune(row[j])); col.Type == synth.String && n > 0 {
+				Expect(t, true, n >= col.MinLength && n <= col.MaxLength, col.Name, row[j])
+			}
+		}
+
+		id, err := strconv.Atoi(row[0])
+		Expect(t, nil, err)
+		Expect(t, true, id >= 1 && id <= 300)
+
+		amount, _ := strconv.ParseFloat(row[5], 64)
+		sum += amount
+		if row[4] == "active" {
+			active++
+		}
+		if row[8] == "" {
+			nulls++
+		}
+	}
+
+	Expect(t, true, math.Abs(sum/2000-50) < 2, sum/2000)
+	Expect(t, true, math.Abs(float64(active)/2000-0.75) < 0.08, active)
+	Expect(t, true, math.Abs(float64(nulls)/2000-0.5) < 0.08, nulls)
+
+	// Reproducible from the seed
+	Expect(t, fmt.Sprint(p.Generate(fakery.NewFromSeed(1), 5)), fmt.Sprint(p.Generate(fakery.NewFromSeed(1), 5)))
+}
+
+func TestSynthNeverCopies(t *testing.T) {
+	// Every value of the shape [A-Z]{2} is in the sample, but NA
+	// which is read as null
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	w.Write([]string{"code"})
+	real := make(map[string]bool)
+	for a := 'A'; a <= 'Z'; a++ {
+		for b := 'A'; b <= 'Z'; b++ {
+			if string(a)+string(b) == "NA" {
+				continue
+			}
+			real[string(a)+string(b)] = true
+			w.Write([]string{string(a) + string(b)})
+		}
+	}
+	w.Flush()
+
+	p, err := synth.ProfileCSV(&buf)
+	Expect(t, nil, err)
+	for _, row := range p.Generate(fakery.NewFromSeed(43), 200) {
+		Expect(t, false, real[row[0]], row[0])
+		Expect(t, 2, len(row[0]), row[0])
+	}
+}
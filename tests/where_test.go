@@ -0,0 +1,85 @@
+package tests
+
+import (
+	"errors"
+	"fakery"
+	"strings"
+	"testing"
+)
+
+func TestWhere(t *testing.T) {
+	f := fakery.NewFromSeed(44)
+
+	car, err := fakery.Where(f, (*fakery.Fakery).Car, func(c *fakery.Car) bool {
+		return c.Year > 2020 && c.Type == "Electric"
+	})
+	Expect(t, nil, err)
+	Expect(t, true, car.Year > 2020)
+	Expect(t, "Electric", car.Type)
+
+	gen, _ := fakery.LookupGenerator("person.first_name")
+	name, err := f.Where(gen, func(v interface{}) bool {
+		return strings.HasPrefix(v.(string), "A")
+	})
+	Expect(t, nil, err)
+	Expect(t, true, strings.HasPrefix(name.(string), "A"))
+
+	_, err = fakery.Where(f, (*fakery.Fakery).Name, func(string) bool { return false })
+	Expect(t, true, errors.Is(err, fakery.ErrNoMatch))
+}
+
+func TestPersonWith(t *testing.T) {
+	f := fakery.NewFromSeed(44)
+
+	for i := 0; i < 20; i++ {
+		p := f.PersonWith(fakery.WithGender(fakery.GenderFemale), fakery.WithLastName("Smith"))
+		Expect(t, "Female", p.Gender)
+		Expect(t, "Smith", p.LastName)
+		Expect(t, p.FirstName+" Smith", p.Name)
+		Expect(t, true, strings.Contains(p.FullName, p.Name))
+		Expect(t, true, strings.Contains(p.Email, "smith"))
+
+		p = f.PersonWith(fakery.WithAge(65, 70))
+		Expect(t, true, p.Age() >= 65 && p.Age() <= 70, p.Birthdate)
+		p = f.PersonWith(fakery.WithAge(40, 40))
+		Expect(t, 40, p.Age(), p.Birthdate)
+		p = f.PersonWith(fakery.WithAge(30, 20))
+		Expect(t, true, p.Age() >= 20 && p.Age() <= 30, p.Birthdate)
+
+		// The gender follows a pinned first name
+		p = f.PersonWith(fakery.WithFirstName("Mary"))
+		Expect(t, "Female", p.Gender)
+		Expect(t, true, strings.HasPrefix(p.Email, "mary.") || strings.Contains(p.Email, ".mary@"))
+	}
+
+	// Default ages do not depend on today
+	p := fakery.NewFromSeed(1).Person()
+	Expect(t, p.Birthdate, fakery.NewFromSeed(1).Person().Birthdate)
+	Expect(t, false, p.DateOfBirth().IsZero())
+	Expect(t, true, p.Age() >= 18)
+}
+
+func TestAddressWith(t *testing.T) {
+	f := fakery.NewFromSeed(44)
+
+	a := f.AddressWith(fakery.WithState("Texas"), fakery.WithCity("Austin"))
+	Expect(t, "Texas", a.State)
+	Expect(t, "Austin", a.City)
+	Expect(t, true, strings.Contains(a.FullAddress, "Austin"))
+	Expect(t, true, strings.Contains(a.FullAddress, "Texas"))
+}
+
+func TestCarWith(t *testing.T) {
+	f := fakery.NewFromSeed(44)
+
+	for i := 0; i < 10; i++ {
+		c := f.CarWith(fakery.WithMake("toyota"), fakery.WithTransmission("Manual"))
+		Expect(t, "Toyota", c.Make)
+		NotExpect(t, "", c.Model)
+		Expect(t, "Manual", c.Transmission)
+	}
+
+	c := f.CarWith(fakery.WithMake("Trabbi"))
+	Expect(t, "Trabbi", c.Make)
+	Expect(t, "", c.Model)
+}
@@ -0,0 +1,39 @@
+// Values satisfying constraints by rejection sampling
+package fakery
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Number of values Where draws before giving up
+var WhereAttempts = 1000
+
+// Returned by Where when no value satisfied the predicate
+var ErrNoMatch = errors.New("error - no value matched the predicate")
+
+// Return a value from gen for which pred holds, e.g:
+//
+//	car, err := fakery.Where(f, (*fakery.Fakery).Car, func(c *fakery.Car) bool {
+//		return c.Year > 2020 && c.Type == "Electric"
+//	})
+//
+// At most WhereAttempts values are drawn, after which an error
+// wrapping ErrNoMatch is returned. Rare constraints are better
+// pinned with options such as PersonWith.
+func Where[T any](f *Fakery, gen func(*Fakery) T, pred func(T) bool) (T, error) {
+	for i := 0; i < WhereAttempts; i++ {
+		if v := gen(f); pred(v) {
+			return v, nil
+		}
+	}
+
+	var zero T
+	return zero, fmt.Errorf("%w in %d attempts", ErrNoMatch, WhereAttempts)
+}
+
+// Return a value from the registered generator for which pred
+// holds, see the function Where
+func (f *Fakery) Where(gen Generator, pred func(interface{}) bool) (interface{}, error) {
+	return Where(f, gen, pred)
+}